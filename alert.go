@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2019 Dell EMC Corporation
+All Rights Reserved
+*/
+
+package gounity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dell/gounity/api"
+	"github.com/dell/gounity/types"
+)
+
+//Alert severity constants, a lower value is more severe
+const (
+	AlertSeverityCritical = 2
+	AlertSeverityError    = 3
+	AlertSeverityWarning  = 4
+	AlertSeverityNotice   = 5
+	AlertSeverityInfo     = 6
+)
+
+//Alert structure
+type Alert struct {
+	client *Client
+}
+
+//NewAlert returns alert
+func NewAlert(client *Client) *Alert {
+	return &Alert{client}
+}
+
+//ListAlerts - List the alerts of the array with the given severity or a more severe one (Ex: AlertSeverityWarning lists
+//the warnings, errors and more severe alerts)
+func (a *Alert) ListAlerts(ctx context.Context, severity int) ([]types.Alert, error) {
+	alertsResp := &types.ListAlerts{}
+	filter := fmt.Sprintf("severity le %d", severity)
+	err := a.client.QueryInstances(ctx, api.AlertAction, strings.Split(AlertDisplayFields, ","), filter, alertsResp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list alerts with severity: %d. Error: %w", severity, err)
+	}
+	return alertsResp.Alerts, nil
+}
+
+//AcknowledgeAlert - Acknowledge the alert, Ex: once a ticket is created for it
+func (a *Alert) AcknowledgeAlert(ctx context.Context, alertID string) error {
+	if len(alertID) == 0 {
+		return errors.New("alert Id shouldn't be empty")
+	}
+	alertReq := types.AlertModifyParam{
+		IsAcknowledged: true,
+	}
+	err := a.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityModifyAlertURI, api.AlertAction, alertID), alertReq, nil)
+	if err != nil {
+		return fmt.Errorf("acknowledge alert: %s failed. Error: %v", alertID, err)
+	}
+	return nil
+}
+
+//DeleteAlert - Delete the alert from the array
+func (a *Alert) DeleteAlert(ctx context.Context, alertID string) error {
+	if len(alertID) == 0 {
+		return errors.New("alert Id shouldn't be empty")
+	}
+	err := a.client.executeWithRetryAuthenticate(ctx, http.MethodDelete, fmt.Sprintf(api.UnityAPIGetResourceURI, api.AlertAction, alertID), nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete alert: %s failed. Error: %v", alertID, err)
+	}
+	return nil
+}
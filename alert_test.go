@@ -0,0 +1,56 @@
+package gounity
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestAlert(t *testing.T) {
+	requireArray(t)
+	ctx = context.Background()
+
+	listAlertsTest(t)
+	modifyAlertTest(t)
+}
+
+func listAlertsTest(t *testing.T) {
+
+	fmt.Println("Begin - List Alerts Test")
+
+	alerts, err := testConf.alertAPI.ListAlerts(ctx, AlertSeverityWarning)
+	if err != nil {
+		t.Fatalf("List alerts failed: %v", err)
+	}
+	for _, alert := range alerts {
+		if alert.AlertContent.Severity > AlertSeverityWarning {
+			t.Fatalf("List alerts returned alert %s with severity %d", alert.AlertContent.ID, alert.AlertContent.Severity)
+		}
+	}
+	fmt.Println("List alerts:", len(alerts))
+
+	fmt.Println("List Alerts Test - Successful")
+}
+
+func modifyAlertTest(t *testing.T) {
+
+	fmt.Println("Begin - Modify Alert Test")
+
+	//Alerts of the array are left untouched, only invalid requests are sent
+	err := testConf.alertAPI.AcknowledgeAlert(ctx, "")
+	if err == nil {
+		t.Fatalf("Acknowledge alert with empty Id case - failed: %v", err)
+	}
+
+	err = testConf.alertAPI.AcknowledgeAlert(ctx, "dummy_alert_1")
+	if err == nil {
+		t.Fatalf("Acknowledge alert with invalid Id case - failed: %v", err)
+	}
+
+	err = testConf.alertAPI.DeleteAlert(ctx, "dummy_alert_1")
+	if err == nil {
+		t.Fatalf("Delete alert with invalid Id case - failed: %v", err)
+	}
+
+	fmt.Println("Modify Alert Test - Successful")
+}
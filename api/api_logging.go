@@ -9,14 +9,57 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httputil"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 )
 
+//redactedValue replaces the value of the sensitive fields in the logged request bodies
+const redactedValue = "******"
+
+//isSensitiveField returns true for the fields holding credentials (passwords, CHAP secrets), which are never logged
+func isSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(name, "password") || strings.Contains(name, "secret")
+}
+
+//redactBody returns the JSON encoding of the request body with the values of the sensitive fields redacted
+func redactBody(body interface{}) string {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return ""
+	}
+	var decoded interface{}
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		return ""
+	}
+	redacted, _ := json.Marshal(redactValue(decoded))
+	return string(redacted)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, fieldValue := range v {
+			if isSensitiveField(key) {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactValue(fieldValue)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+	return value
+}
+
 func isBinOctetBody(h http.Header) bool {
 	return h.Get(HeaderKeyContentType) == headerValContentTypeBinaryOctetStream
 }
@@ -41,6 +41,9 @@ const (
 	//UnityAPILoginSessionInfoURI LOGINS resource URIs
 	UnityAPILoginSessionInfoURI = unityAPITypes + "/loginSessionInfo"
 
+	//UnityAPILogoutURI ends the session of the client
+	UnityAPILogoutURI = UnityAPILoginSessionInfoURI + "/action/logout"
+
 	//UnityAPIBasicSysInfoURI gets BasicSystemInfo URI
 	UnityAPIBasicSysInfoURI = unityAPITypes + "/basicSystemInfo/instances"
 
@@ -70,19 +73,35 @@ const (
 	//UnityModifySnapshotURI Snapshot Action resource URIs
 	UnityModifySnapshotURI = UnityAPIGetResourceURI + "/action/modify"
 
+	//UnityModifyStoragePoolURI Storage Pool modify Action resource URIs
+	UnityModifyStoragePoolURI = UnityAPIGetResourceURI + "/action/modify"
+
+	//UnityModifyNameServerURI NAS server DNS (fileDNSServer) & NIS (fileNISServer) modify Action resource URIs
+	UnityModifyNameServerURI = UnityAPIGetResourceURI + "/action/modify"
+
+	//UnityModifyAlertURI Alert modify Action resource URIs
+	UnityModifyAlertURI = UnityAPIGetResourceURI + "/action/modify"
+
 	//UnityCopySnapshotURI does Snapshot Copy Action
 	UnityCopySnapshotURI = UnityAPIGetResourceURI + "/action/copy"
 
+	//UnitySyncReplicationSessionURI does Replication Session Sync Action
+	UnitySyncReplicationSessionURI = UnityAPIInstancesURI + "/" + ReplicationSessionAction + "/%s/action/sync"
+
 	//UnityListHostInitiatorsURI gets Host Initiator URIs
 	UnityListHostInitiatorsURI = unityAPITypes + "/hostInitiator/instances?fields="
 	UnityModifyHostInitiators  = unityRootAPI + "/instances/hostInitiator/%s/action/modify"
 
+	//UnityModifyISCSISettingsURI modifies the array iSCSI settings (a singleton with Id 0)
+	UnityModifyISCSISettingsURI = unityRootAPI + "/instances/iscsiSettings/0/action/modify"
+
 	//UnityInstancesFilter does Unity Instance Filter
 	UnityInstancesFilter = UnityAPIInstanceTypeResources + "?filter=%s"
 
 	UnityMetric              = "metric"
 	UnityMetricQueryResult   = "metricQueryResult"
 	UnityMetricRealTimeQuery = "metricRealTimeQuery"
+	UnityMetricValue         = "metricValue"
 
 	//Action types for URL's
 
@@ -103,4 +122,17 @@ const (
 	HostIPPortAction        = "hostIPPort"
 	NasServerAction         = "nasServer"
 	TenantAction            = "tenant"
+
+	RemoteSystemAction       = "remoteSystem"
+	ReplicationSessionAction = "replicationSession"
+
+	FileInterfaceAction = "fileInterface"
+	RouteAction         = "route"
+	FileDNSServerAction = "fileDNSServer"
+	FileNISServerAction = "fileNISServer"
+	NfsServerAction     = "nfsServer"
+	TreeQuotaAction     = "treeQuota"
+	UserQuotaAction     = "userQuota"
+	AlertAction         = "alert"
+	SystemTimeAction    = "systemTime"
 )
@@ -2,15 +2,18 @@ package api
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"net/http/cookiejar"
 	"net/url"
 	"strings"
 	"time"
@@ -26,11 +29,16 @@ const (
 	HeaderValContentTypeJSON              = "application/json"
 	headerValContentTypeBinaryOctetStream = "binary/octet-stream"
 	HeaderEMCCSRFToken                    = "EMC-CSRF-TOKEN"
+	HeaderKeyUserAgent                    = "User-Agent"
+	HeaderKeyAcceptEncoding               = "Accept-Encoding"
+	HeaderKeyContentEncoding              = "Content-Encoding"
+	HeaderValEncodingGzip                 = "gzip"
 )
 
 var (
-	errNewClient = errors.New("missing endpoint")
-	errSysCerts  = errors.New("unable to initialize certificate pool from system")
+	errNewClient          = errors.New("missing endpoint")
+	errSysCerts           = errors.New("unable to initialize certificate pool from system")
+	errPinnedCertMismatch = errors.New("server certificate does not match the pinned certificate fingerprint")
 )
 
 // Client Interface defines the methods.
@@ -86,14 +94,45 @@ type Client interface {
 
 	// GetToken gets the Auth token for the HTTP client
 	GetToken() string
+
+	// ClearSession drops the Auth token and the session cookies of the HTTP client
+	ClearSession()
+
+	// SetSessionStore replaces the store holding the Auth token and the session cookies of the HTTP client
+	SetSessionStore(store SessionStore)
+
+	// ExportSession encodes the Auth token and the session cookies of the HTTP client for reuse by another process
+	ExportSession() ([]byte, error)
+
+	// ImportSession restores the Auth token and the session cookies exported by ExportSession
+	ImportSession(data []byte) error
+
+	// SetStrictDecoding makes the client reject responses with fields unknown to the response types
+	SetStrictDecoding(strict bool)
+
+	// SetPinnedCertificate makes the client trust only the server certificate with the given SHA-256 fingerprint.
+	// The other TLS settings of the transport are kept, a transport other than *http.Transport is rejected.
+	SetPinnedCertificate(fingerprint string) error
+
+	// WithHost returns a copy of the client sending requests to the given host.
+	// The transport is cloned & the timeout & logging options are copied, the session (token & cookies) and a
+	// pinned certificate are not.
+	WithHost(host string) (Client, error)
+
+	// Host returns the host the client sends requests to
+	Host() string
 }
 
 type client struct {
-	http     *http.Client
-	host     string
-	token    string
-	showHTTP bool
-	debug    bool
+	http           *http.Client
+	host           string
+	session        SessionStore
+	sessionCreated time.Time
+	showHTTP       bool
+	debug          bool
+	strictDecoding bool
+	// transport of the client before a certificate was pinned, the pin is not carried over to other hosts
+	unpinnedTransport http.RoundTripper
 }
 
 // ClientOptions are options for the API client.
@@ -107,6 +146,10 @@ type ClientOptions struct {
 	// ShowHTTP is a flag that indicates whether or not HTTP requests and
 	// responses should be logged to stdout
 	ShowHTTP bool
+
+	// Proxy is the URL of the proxy the requests are sent through (Ex: http://proxy.example.com:3128).
+	// When empty, the proxy is taken from the HTTP_PROXY, HTTPS_PROXY & NO_PROXY environment variables.
+	Proxy string
 }
 
 //New returns a new API client.
@@ -117,20 +160,31 @@ func New(ctx context.Context, host string, opts ClientOptions, debug bool) (Clie
 
 	host = strings.Replace(host, "/api", "", 1)
 
-	cookieJar, _ := cookiejar.New(nil)
+	session := NewSessionStore()
 
 	c := &client{
-		http:  &http.Client{},
-		host:  host,
-		debug: debug,
+		http:    &http.Client{},
+		host:    host,
+		session: session,
+		debug:   debug,
 	}
 
 	if opts.Timeout != 0 {
 		c.http.Timeout = opts.Timeout
 	}
 
+	proxy := http.ProxyFromEnvironment
+	if opts.Proxy != "" {
+		proxyURL, err := url.Parse(opts.Proxy)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL: %s", opts.Proxy)
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+
 	if opts.Insecure {
 		c.http.Transport = &http.Transport{
+			Proxy: proxy,
 			TLSClientConfig: &tls.Config{
 				InsecureSkipVerify: true,
 			},
@@ -141,19 +195,41 @@ func New(ctx context.Context, host string, opts ClientOptions, debug bool) (Clie
 			return nil, errSysCerts
 		}
 		c.http.Transport = &http.Transport{
+			Proxy: proxy,
 			TLSClientConfig: &tls.Config{
 				RootCAs:            pool,
 				InsecureSkipVerify: false,
 			},
 		}
 	}
-	c.http.Jar = cookieJar
+	c.http.Jar = session
 	if opts.ShowHTTP {
 		c.showHTTP = true
 	}
 	return c, nil
 }
 
+//NewWithHTTPClient returns a new API client sending the requests through the given HTTP client (Ex: with a proxy or a
+//tracing transport). The HTTP client is copied, the session cookie jar of the copy is replaced, the transport & timeout
+//are used as given.
+func NewWithHTTPClient(ctx context.Context, host string, httpClient *http.Client, opts ClientOptions, debug bool) (Client, error) {
+	if host == "" || httpClient == nil {
+		return nil, errNewClient
+	}
+
+	session := NewSessionStore()
+	clientCopy := *httpClient
+	clientCopy.Jar = session
+
+	return &client{
+		http:     &clientCopy,
+		host:     strings.Replace(host, "/api", "", 1),
+		session:  session,
+		showHTTP: opts.ShowHTTP,
+		debug:    debug,
+	}, nil
+}
+
 // Makes a GET call to the Unity REST API Server with the given path & headers
 func (c *client) Get(ctx context.Context, path string, headers map[string]string, resp interface{}) error {
 	return c.DoWithHeaders(ctx, http.MethodGet, path, headers, nil, resp)
@@ -271,8 +347,8 @@ func (c *client) DoAndGetResponseBody(ctx context.Context, method, uri string, h
 	}
 
 	// set the auth token for POST and DELETE methods only
-	if (method == "POST" || method == "DELETE") && c.token != "" {
-		req.Header.Set(HeaderEMCCSRFToken, c.token)
+	if token := c.session.Get(); (method == "POST" || method == "DELETE") && token != "" {
+		req.Header.Set(HeaderEMCCSRFToken, token)
 	}
 
 	if c.showHTTP {
@@ -285,6 +361,11 @@ func (c *client) DoAndGetResponseBody(ctx context.Context, method, uri string, h
 		return nil, err
 	}
 
+	if err = decompressResponse(res); err != nil {
+		res.Body.Close()
+		return nil, err
+	}
+
 	if c.showHTTP {
 		logResponse(ctx, res, c.doLog)
 	}
@@ -293,16 +374,48 @@ func (c *client) DoAndGetResponseBody(ctx context.Context, method, uri string, h
 	return res, err
 }
 
+// gzipReadCloser closes both the gzip reader and the underlying response body
+type gzipReadCloser struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (g *gzipReadCloser) Close() error {
+	g.Reader.Close()
+	return g.body.Close()
+}
+
+// decompressResponse replaces a gzip encoded response body with the decompressed body.
+// Go's transport only decompresses transparently when it added the Accept-Encoding header itself.
+func decompressResponse(res *http.Response) error {
+	if !strings.EqualFold(res.Header.Get(HeaderKeyContentEncoding), HeaderValEncodingGzip) {
+		return nil
+	}
+	gz, err := gzip.NewReader(res.Body)
+	if err == io.EOF {
+		// empty body, nothing to decompress
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to decompress gzip response: %v", err)
+	}
+	res.Body = &gzipReadCloser{Reader: gz, body: res.Body}
+	res.Header.Del(HeaderKeyContentEncoding)
+	res.Header.Del("Content-Length")
+	res.ContentLength = -1
+	res.Uncompressed = true
+	return nil
+}
+
 func (c *client) DoWithHeaders(ctx context.Context, method, uri string, headers map[string]string, body, resp interface{}) error {
 	log := util.GetRunIDLogger(ctx)
 	if body != nil {
-		data, _ := json.Marshal(body)
-		strBody := strings.ReplaceAll(string(data), "\"", "")
+		strBody := strings.ReplaceAll(redactBody(body), "\"", "")
 		log.Debugf("Request Body: %s", strBody)
 	}
 	res, err := c.DoAndGetResponseBody(ctx, method, uri, headers, body)
 	if err != nil {
-		return fmt.Errorf("Error while receiving response for url: %s error: %v", uri, err)
+		return fmt.Errorf("Error while receiving response for url: %s error: %w", uri, err)
 	}
 	defer res.Body.Close()
 
@@ -311,7 +424,18 @@ func (c *client) DoWithHeaders(ctx context.Context, method, uri string, headers
 	case res == nil:
 		return fmt.Errorf("Nil Response received for url: %s", uri)
 	case res.StatusCode >= 200 && res.StatusCode <= 299:
-		dec := json.NewDecoder(res.Body)
+		data, err := io.ReadAll(res.Body)
+		if err != nil {
+			return fmt.Errorf("Error while reading response for url: %s error: %w", uri, err)
+		}
+		if jsonError := sessionExpiredError(data); jsonError != nil {
+			log.Debugf("Session expired response received with status %s for url: %s", res.Status, uri)
+			return jsonError
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		if c.strictDecoding {
+			dec.DisallowUnknownFields()
+		}
 		if resp != nil {
 			if err = dec.Decode(resp); err != nil && err != io.EOF {
 				c.doLog(log.WithError(err).Error, fmt.Sprintf("Unable to decode response into %+v", resp))
@@ -329,19 +453,165 @@ func (c *client) DoWithHeaders(ctx context.Context, method, uri string, headers
 		jsonError.ErrorContent.HTTPStatusCode = res.StatusCode
 		jsonError.ErrorContent.Message = append(jsonError.ErrorContent.Message, types.ErrorMessage{EnUS: string(res.Status)})
 		return jsonError
+	case res.StatusCode == http.StatusServiceUnavailable:
+		//the body of a 503 is not always a Unity JSON error (Ex: an HTML page during upgrades), the status and the text
+		//of the body are kept in the message then, so that the reason of the unavailability is not lost
+		jsonError := &types.Error{}
+		data, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBodyRead))
+		if err := json.Unmarshal(data, jsonError); err != nil || len(jsonError.ErrorContent.Message) == 0 {
+			message := res.Status
+			if text := errorBodyText(data); text != "" {
+				message = fmt.Sprintf("%s: %s", res.Status, text)
+			}
+			jsonError.ErrorContent.Message = append(jsonError.ErrorContent.Message, types.ErrorMessage{EnUS: message})
+		}
+		jsonError.ErrorContent.HTTPStatusCode = res.StatusCode
+		return jsonError
 	default:
-		log.Debugf("Invalid Response received Body: %s error: %v", body, err)
+		log.Debugf("Invalid Response received Body: %s error: %v", redactBody(body), err)
 		return c.ParseJSONError(ctx, res)
 	}
 	return nil
 }
 
+//maxErrorBodyRead is the maximum size of a non JSON error body read, maxErrorBodyText the maximum length of its text
+//kept in the error message
+const (
+	maxErrorBodyRead = 64 * 1024
+	maxErrorBodyText = 256
+)
+
+//errorBodyText returns the text of a non JSON error body with the whitespace collapsed, truncated to maxErrorBodyText
+func errorBodyText(data []byte) string {
+	text := strings.Join(strings.Fields(string(data)), " ")
+	if len(text) > maxErrorBodyText {
+		text = text[:maxErrorBodyText] + "..."
+	}
+	return text
+}
+
+//sessionStatus captures the markers of a successful response whose body reports an expired session
+type sessionStatus struct {
+	LoggedOut bool                `json:"loggedOut"`
+	Error     *types.ErrorContent `json:"error"`
+}
+
+//sessionExpiredError returns a 401 Unity error when the body of a successful response reports that the session is no
+//longer valid (Ex: {"loggedOut":true}), so that the caller re-authenticates instead of decoding it as the resource
+func sessionExpiredError(data []byte) *types.Error {
+	if !bytes.Contains(data, []byte(`"loggedOut"`)) && !bytes.Contains(data, []byte(`"error"`)) {
+		return nil
+	}
+	status := sessionStatus{}
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil
+	}
+	if !status.LoggedOut && (status.Error == nil || status.Error.HTTPStatusCode != http.StatusUnauthorized) {
+		return nil
+	}
+	jsonError := &types.Error{}
+	if status.Error != nil {
+		jsonError.ErrorContent = *status.Error
+	}
+	jsonError.ErrorContent.HTTPStatusCode = http.StatusUnauthorized
+	if len(jsonError.ErrorContent.Message) == 0 {
+		jsonError.ErrorContent.Message = append(jsonError.ErrorContent.Message, types.ErrorMessage{EnUS: "session expired"})
+	}
+	return jsonError
+}
+
 func (c *client) SetToken(token string) {
-	c.token = token
+	c.session.Set(token)
+	c.sessionCreated = time.Now()
 }
 
 func (c *client) GetToken() string {
-	return c.token
+	return c.session.Get()
+}
+
+func (c *client) ClearSession() {
+	c.session.Clear()
+	c.sessionCreated = time.Time{}
+}
+
+func (c *client) SetSessionStore(store SessionStore) {
+	c.session = store
+	c.http.Jar = store
+}
+
+func (c *client) SetStrictDecoding(strict bool) {
+	c.strictDecoding = strict
+}
+
+func (c *client) SetPinnedCertificate(fingerprint string) error {
+	pinned, err := hex.DecodeString(strings.ReplaceAll(strings.TrimSpace(fingerprint), ":", ""))
+	if err != nil || len(pinned) != sha256.Size {
+		return fmt.Errorf("invalid SHA-256 certificate fingerprint: %s", fingerprint)
+	}
+
+	current := c.http.Transport
+	if c.unpinnedTransport != nil {
+		current = c.unpinnedTransport
+	}
+	var transport *http.Transport
+	switch t := current.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		return fmt.Errorf("unable to pin the certificate on a transport of type %T", current)
+	}
+	tlsConfig := &tls.Config{}
+	if transport.TLSClientConfig != nil {
+		tlsConfig = transport.TLSClientConfig.Clone()
+	}
+	// the chain is not verified against any CA, the presented leaf certificate must match the pinned fingerprint instead
+	tlsConfig.InsecureSkipVerify = true
+	tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errPinnedCertMismatch
+		}
+		presented := sha256.Sum256(rawCerts[0])
+		if subtle.ConstantTimeCompare(presented[:], pinned) != 1 {
+			return errPinnedCertMismatch
+		}
+		return nil
+	}
+	transport.TLSClientConfig = tlsConfig
+	c.unpinnedTransport = current
+	c.http.Transport = transport
+	return nil
+}
+
+func (c *client) WithHost(host string) (Client, error) {
+	if host == "" {
+		return nil, errNewClient
+	}
+
+	session := NewSessionStore()
+	httpClient := *c.http
+	httpClient.Jar = session
+	// the pinned certificate belongs to this host, the new host gets the transport as it was before pinning
+	if c.unpinnedTransport != nil {
+		httpClient.Transport = c.unpinnedTransport
+	}
+	if transport, ok := httpClient.Transport.(*http.Transport); ok {
+		httpClient.Transport = transport.Clone()
+	}
+
+	return &client{
+		http:           &httpClient,
+		host:           strings.Replace(host, "/api", "", 1),
+		session:        session,
+		showHTTP:       c.showHTTP,
+		debug:          c.debug,
+		strictDecoding: c.strictDecoding,
+	}, nil
+}
+
+func (c *client) Host() string {
+	return c.host
 }
 
 func (c *client) ParseJSONError(ctx context.Context, r *http.Response) error {
@@ -0,0 +1,603 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dell/gounity/types"
+	"github.com/dell/gounity/util"
+	"github.com/sirupsen/logrus"
+)
+
+type testResource struct {
+	Content struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"content"`
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestRestClient(t *testing.T) {
+	gzipResponseTest(t)
+	plainResponseTest(t)
+	withHostTest(t)
+	withHostPinnedCertificateTest(t)
+	pinnedCertificateTest(t)
+	pinnedCertificateTLSConfigTest(t)
+	redactBodyTest(t)
+	strictDecodingTest(t)
+	serviceUnavailableTest(t)
+	sessionStoreTest(t)
+	newWithHTTPClientTest(t)
+	sessionExpiredTest(t)
+	exportSessionTest(t)
+	proxyTest(t)
+}
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (Client, *httptest.Server) {
+	server := httptest.NewServer(handler)
+	c, err := New(context.Background(), server.URL, ClientOptions{Insecure: true}, false)
+	if err != nil {
+		server.Close()
+		t.Fatalf("Create API client failed: %v", err)
+	}
+	return c, server
+}
+
+func gzipResponseTest(t *testing.T) {
+	fmt.Println("Begin - Gzip Response Test")
+
+	c, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(HeaderKeyAcceptEncoding) != HeaderValEncodingGzip {
+			t.Errorf("Accept-Encoding header not sent, received: %s", r.Header.Get(HeaderKeyAcceptEncoding))
+		}
+		w.Header().Set(HeaderKeyContentType, HeaderValContentTypeJSON)
+		w.Header().Set(HeaderKeyContentEncoding, HeaderValEncodingGzip)
+		gz := gzip.NewWriter(w)
+		fmt.Fprint(gz, `{"content":{"id":"fs_1","name":"gzip-fs"}}`)
+		gz.Close()
+	})
+	defer server.Close()
+
+	headers := map[string]string{HeaderKeyAcceptEncoding: HeaderValEncodingGzip}
+	resp := &testResource{}
+	err := c.DoWithHeaders(context.Background(), http.MethodGet, "/api/instances/filesystem/fs_1", headers, nil, resp)
+	if err != nil {
+		t.Fatalf("Decode gzip response failed: %v", err)
+	}
+	if resp.Content.ID != "fs_1" || resp.Content.Name != "gzip-fs" {
+		t.Fatalf("Decode gzip response returned unexpected content: %+v", resp.Content)
+	}
+
+	fmt.Println("Gzip Response Test Successful")
+}
+
+func plainResponseTest(t *testing.T) {
+	fmt.Println("Begin - Plain Response Test")
+
+	c, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderKeyContentType, HeaderValContentTypeJSON)
+		fmt.Fprint(w, `{"content":{"id":"fs_2","name":"plain-fs"}}`)
+	})
+	defer server.Close()
+
+	headers := map[string]string{HeaderKeyAcceptEncoding: HeaderValEncodingGzip}
+	resp := &testResource{}
+	err := c.DoWithHeaders(context.Background(), http.MethodGet, "/api/instances/filesystem/fs_2", headers, nil, resp)
+	if err != nil {
+		t.Fatalf("Decode plain response failed: %v", err)
+	}
+	if resp.Content.ID != "fs_2" {
+		t.Fatalf("Decode plain response returned unexpected content: %+v", resp.Content)
+	}
+
+	fmt.Println("Plain Response Test Successful")
+}
+
+func withHostTest(t *testing.T) {
+	fmt.Println("Begin - With Host Test")
+
+	c, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Request sent to the original host: %s", r.URL.Path)
+	})
+	defer server.Close()
+	c.SetToken("token-1")
+
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderKeyContentType, HeaderValContentTypeJSON)
+		fmt.Fprint(w, `{"content":{"id":"fs_3","name":"other-fs"}}`)
+	}))
+	defer other.Close()
+
+	clone, err := c.WithHost(other.URL)
+	if err != nil {
+		t.Fatalf("With host failed: %v", err)
+	}
+	if clone.GetToken() != "" {
+		t.Fatalf("With host copied the session token: %s", clone.GetToken())
+	}
+
+	resp := &testResource{}
+	err = clone.DoWithHeaders(context.Background(), http.MethodGet, "/api/instances/filesystem/fs_3", nil, nil, resp)
+	if err != nil {
+		t.Fatalf("Request through cloned client failed: %v", err)
+	}
+	if resp.Content.ID != "fs_3" {
+		t.Fatalf("Request through cloned client returned unexpected content: %+v", resp.Content)
+	}
+
+	//Negative case
+	_, err = c.WithHost("")
+	if err == nil {
+		t.Fatalf("With host with empty host case - failed: %v", err)
+	}
+
+	fmt.Println("With Host Test Successful")
+}
+
+func withHostPinnedCertificateTest(t *testing.T) {
+	fmt.Println("Begin - With Host Pinned Certificate Test")
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderKeyContentType, HeaderValContentTypeJSON)
+		fmt.Fprint(w, `{"content":{"id":"fs_5","name":"pinned-fs"}}`)
+	}))
+	defer server.Close()
+
+	//the certificate of another array is pinned on the original client
+	c, err := NewWithHTTPClient(context.Background(), server.URL, server.Client(), ClientOptions{}, false)
+	if err != nil {
+		t.Fatalf("Create API client with HTTP client failed: %v", err)
+	}
+	otherFingerprint := sha256.Sum256([]byte("other array certificate"))
+	err = c.SetPinnedCertificate(hex.EncodeToString(otherFingerprint[:]))
+	if err != nil {
+		t.Fatalf("Set pinned certificate failed: %v", err)
+	}
+	resp := &testResource{}
+	err = c.DoWithHeaders(context.Background(), http.MethodGet, "/api/instances/filesystem/fs_5", nil, nil, resp)
+	if err == nil {
+		t.Fatalf("Request with mismatching pinned certificate case - failed: %v", err)
+	}
+
+	clone, err := c.WithHost(server.URL)
+	if err != nil {
+		t.Fatalf("With host failed: %v", err)
+	}
+	if clone.(*client).http.Transport == c.(*client).http.Transport {
+		t.Fatalf("With host shared the transport of the original client")
+	}
+	err = clone.DoWithHeaders(context.Background(), http.MethodGet, "/api/instances/filesystem/fs_5", nil, nil, resp)
+	if err != nil {
+		t.Fatalf("Request through cloned client used the pinned certificate of the original client: %v", err)
+	}
+
+	fmt.Println("With Host Pinned Certificate Test Successful")
+}
+
+func pinnedCertificateTest(t *testing.T) {
+	fmt.Println("Begin - Pinned Certificate Test")
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderKeyContentType, HeaderValContentTypeJSON)
+		fmt.Fprint(w, `{"content":{"id":"fs_4","name":"pinned-fs"}}`)
+	}))
+	defer server.Close()
+
+	fingerprint := sha256.Sum256(server.Certificate().Raw)
+
+	c, err := New(context.Background(), server.URL, ClientOptions{}, false)
+	if err != nil {
+		t.Fatalf("Create API client failed: %v", err)
+	}
+	err = c.SetPinnedCertificate(hex.EncodeToString(fingerprint[:]))
+	if err != nil {
+		t.Fatalf("Set pinned certificate failed: %v", err)
+	}
+
+	resp := &testResource{}
+	err = c.DoWithHeaders(context.Background(), http.MethodGet, "/api/instances/filesystem/fs_4", nil, nil, resp)
+	if err != nil {
+		t.Fatalf("Request with pinned certificate failed: %v", err)
+	}
+
+	//Negative cases
+	err = c.SetPinnedCertificate("dummy-fingerprint")
+	if err == nil {
+		t.Fatalf("Set pinned certificate with invalid fingerprint case - failed: %v", err)
+	}
+
+	otherFingerprint := sha256.Sum256([]byte("other certificate"))
+	err = c.SetPinnedCertificate(hex.EncodeToString(otherFingerprint[:]))
+	if err != nil {
+		t.Fatalf("Set pinned certificate failed: %v", err)
+	}
+	err = c.DoWithHeaders(context.Background(), http.MethodGet, "/api/instances/filesystem/fs_4", nil, nil, resp)
+	if err == nil {
+		t.Fatalf("Request with mismatching pinned certificate case - failed: %v", err)
+	}
+
+	wrapped := &http.Client{Transport: roundTripperFunc(http.DefaultTransport.RoundTrip)}
+	c, err = NewWithHTTPClient(context.Background(), server.URL, wrapped, ClientOptions{}, false)
+	if err != nil {
+		t.Fatalf("Create API client with HTTP client failed: %v", err)
+	}
+	err = c.SetPinnedCertificate(hex.EncodeToString(fingerprint[:]))
+	if err == nil {
+		t.Fatalf("Set pinned certificate on a wrapping transport case - failed: %v", err)
+	}
+	if _, ok := c.(*client).http.Transport.(roundTripperFunc); !ok {
+		t.Fatalf("Set pinned certificate replaced the wrapping transport: %T", c.(*client).http.Transport)
+	}
+
+	fmt.Println("Pinned Certificate Test Successful")
+}
+
+func pinnedCertificateTLSConfigTest(t *testing.T) {
+	fmt.Println("Begin - Pinned Certificate TLS Config Test")
+
+	clientCert := tls.Certificate{Certificate: [][]byte{[]byte("client certificate")}}
+	transport := &http.Transport{
+		Proxy: http.ProxyURL(&url.URL{Scheme: "http", Host: "proxy.example.com:3128"}),
+		TLSClientConfig: &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{clientCert},
+		},
+	}
+	c, err := NewWithHTTPClient(context.Background(), "https://array.example.com", &http.Client{Transport: transport}, ClientOptions{}, false)
+	if err != nil {
+		t.Fatalf("Create API client with HTTP client failed: %v", err)
+	}
+
+	fingerprint := sha256.Sum256([]byte("array certificate"))
+	err = c.SetPinnedCertificate(hex.EncodeToString(fingerprint[:]))
+	if err != nil {
+		t.Fatalf("Set pinned certificate failed: %v", err)
+	}
+
+	pinned, ok := c.(*client).http.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Set pinned certificate returned a transport of type %T", c.(*client).http.Transport)
+	}
+	if pinned == transport || transport.TLSClientConfig.VerifyPeerCertificate != nil {
+		t.Fatalf("Set pinned certificate modified the given transport")
+	}
+	if pinned.Proxy == nil {
+		t.Fatalf("Set pinned certificate dropped the proxy of the transport")
+	}
+	tlsConfig := pinned.TLSClientConfig
+	if tlsConfig.MinVersion != tls.VersionTLS12 || len(tlsConfig.Certificates) != 1 || tlsConfig.VerifyPeerCertificate == nil {
+		t.Fatalf("Set pinned certificate did not keep the TLS config of the transport: %+v", tlsConfig)
+	}
+
+	fmt.Println("Pinned Certificate TLS Config Test Successful")
+}
+
+func redactBodyTest(t *testing.T) {
+	fmt.Println("Begin - Redact Body Test")
+
+	body := map[string]interface{}{
+		"chapUserName": "chap-user",
+		"chapSecret":   "chap-secret-1",
+		"nested":       []interface{}{map[string]interface{}{"password": "password-1"}},
+	}
+	redacted := redactBody(body)
+	if strings.Contains(redacted, "chap-secret-1") || strings.Contains(redacted, "password-1") {
+		t.Fatalf("Redact body did not redact the secrets: %s", redacted)
+	}
+	if !strings.Contains(redacted, "chap-user") {
+		t.Fatalf("Redact body redacted a non sensitive field: %s", redacted)
+	}
+
+	c, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderKeyContentType, HeaderValContentTypeJSON)
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"error":{"errorCode":131149829,"httpStatusCode":422,"messages":[{"en-US":"Invalid CHAP secret."}]}}`)
+	})
+	defer server.Close()
+	output := &bytes.Buffer{}
+	logger := logrus.New()
+	logger.SetOutput(output)
+	logger.SetLevel(logrus.DebugLevel)
+	ctx := util.WithLogger(context.Background(), logrus.NewEntry(logger))
+	err := c.DoWithHeaders(ctx, http.MethodPost, "/api/instances/hostInitiator/1/action/modify", nil, body, nil)
+	if err == nil {
+		t.Fatal("Request with an error response - Negative case failed")
+	}
+	if strings.Contains(output.String(), "chap-secret-1") || strings.Contains(output.String(), "password-1") {
+		t.Fatalf("Body of a request with an error response logged without redaction: %s", output.String())
+	}
+
+	fmt.Println("Redact Body Test Successful")
+}
+
+func strictDecodingTest(t *testing.T) {
+	fmt.Println("Begin - Strict Decoding Test")
+
+	c, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderKeyContentType, HeaderValContentTypeJSON)
+		fmt.Fprint(w, `{"content":{"id":"fs_5","name":"strict-fs","newField":true}}`)
+	})
+	defer server.Close()
+
+	resp := &testResource{}
+	err := c.DoWithHeaders(context.Background(), http.MethodGet, "/api/instances/filesystem/fs_5", nil, nil, resp)
+	if err != nil {
+		t.Fatalf("Lenient decode of unknown field failed: %v", err)
+	}
+
+	c.SetStrictDecoding(true)
+	err = c.DoWithHeaders(context.Background(), http.MethodGet, "/api/instances/filesystem/fs_5", nil, nil, resp)
+	if err == nil {
+		t.Fatalf("Strict decode of unknown field case - failed: %v", err)
+	}
+
+	fmt.Println("Strict Decoding Test Successful")
+}
+
+func serviceUnavailableTest(t *testing.T) {
+	fmt.Println("Begin - Service Unavailable Test")
+
+	c, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderKeyContentType, "text/html")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, "<html>The system is being upgraded</html>")
+	})
+	defer server.Close()
+
+	err := c.DoWithHeaders(context.Background(), http.MethodGet, "/api/instances/filesystem/fs_6", nil, nil, nil)
+	e, ok := err.(*types.Error)
+	if !ok {
+		t.Fatalf("Service unavailable response did not return a Unity error: %v", err)
+	}
+	if e.ErrorContent.HTTPStatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Service unavailable response returned status %d", e.ErrorContent.HTTPStatusCode)
+	}
+	if !strings.Contains(e.Error(), "being upgraded") {
+		t.Fatalf("Service unavailable response did not keep the body text: %v", err)
+	}
+
+	fmt.Println("Service Unavailable Test Successful")
+}
+
+func sessionStoreTest(t *testing.T) {
+	fmt.Println("Begin - Session Store Test")
+
+	var receivedToken, receivedCookie string
+	c, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			http.SetCookie(w, &http.Cookie{Name: "mod_sec_emc", Value: "session-1", Path: "/"})
+		}
+		receivedToken = r.Header.Get(HeaderEMCCSRFToken)
+		receivedCookie = ""
+		if cookie, err := r.Cookie("mod_sec_emc"); err == nil {
+			receivedCookie = cookie.Value
+		}
+		w.Header().Set(HeaderKeyContentType, HeaderValContentTypeJSON)
+		fmt.Fprint(w, `{"content":{"id":"fs_7","name":"session-fs"}}`)
+	})
+	defer server.Close()
+
+	store := NewSessionStore()
+	c.SetSessionStore(store)
+	c.SetToken("token-1")
+	if store.Get() != "token-1" {
+		t.Fatalf("Token not stored in the injected session store: %s", store.Get())
+	}
+
+	resp := &testResource{}
+	err := c.DoWithHeaders(context.Background(), http.MethodGet, "/api/loginSessionInfo", nil, nil, resp)
+	if err != nil {
+		t.Fatalf("Login request failed: %v", err)
+	}
+	err = c.DoWithHeaders(context.Background(), http.MethodPost, "/api/instances/filesystem/fs_7/action/modify", nil, nil, resp)
+	if err != nil {
+		t.Fatalf("Modify request failed: %v", err)
+	}
+	if receivedToken != "token-1" || receivedCookie != "session-1" {
+		t.Fatalf("Session not sent, token: %s cookie: %s", receivedToken, receivedCookie)
+	}
+
+	c.ClearSession()
+	err = c.DoWithHeaders(context.Background(), http.MethodPost, "/api/instances/filesystem/fs_7/action/modify", nil, nil, resp)
+	if err != nil {
+		t.Fatalf("Modify request after clear failed: %v", err)
+	}
+	if receivedToken != "" || receivedCookie != "" {
+		t.Fatalf("Session sent after clear, token: %s cookie: %s", receivedToken, receivedCookie)
+	}
+
+	fmt.Println("Session Store Test Successful")
+}
+
+type countingTransport struct {
+	requests int
+}
+
+func (ct *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ct.requests++
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func newWithHTTPClientTest(t *testing.T) {
+	fmt.Println("Begin - New With HTTP Client Test")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderKeyContentType, HeaderValContentTypeJSON)
+		fmt.Fprint(w, `{"content":{"id":"fs_8","name":"custom-fs"}}`)
+	}))
+	defer server.Close()
+
+	transport := &countingTransport{}
+	httpClient := &http.Client{Transport: transport}
+	c, err := NewWithHTTPClient(context.Background(), server.URL, httpClient, ClientOptions{}, false)
+	if err != nil {
+		t.Fatalf("Create API client with HTTP client failed: %v", err)
+	}
+
+	resp := &testResource{}
+	err = c.DoWithHeaders(context.Background(), http.MethodGet, "/api/instances/filesystem/fs_8", nil, nil, resp)
+	if err != nil {
+		t.Fatalf("Request through custom HTTP client failed: %v", err)
+	}
+	if transport.requests != 1 || resp.Content.ID != "fs_8" {
+		t.Fatalf("Request not sent through the custom transport, requests: %d content: %+v", transport.requests, resp.Content)
+	}
+	if httpClient.Jar != nil {
+		t.Fatalf("Custom HTTP client modified")
+	}
+
+	//Negative case
+	_, err = NewWithHTTPClient(context.Background(), server.URL, nil, ClientOptions{}, false)
+	if err == nil {
+		t.Fatalf("Create API client with nil HTTP client case - failed: %v", err)
+	}
+
+	fmt.Println("New With HTTP Client Test Successful")
+}
+
+func sessionExpiredTest(t *testing.T) {
+	fmt.Println("Begin - Session Expired Test")
+
+	body := `{"loggedOut":true}`
+	c, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderKeyContentType, HeaderValContentTypeJSON)
+		fmt.Fprint(w, body)
+	})
+	defer server.Close()
+
+	resp := &testResource{}
+	err := c.DoWithHeaders(context.Background(), http.MethodGet, "/api/instances/filesystem/fs_9", nil, nil, resp)
+	e, ok := err.(*types.Error)
+	if !ok || e.ErrorContent.HTTPStatusCode != http.StatusUnauthorized {
+		t.Fatalf("Logged out response did not return an authentication error: %v", err)
+	}
+
+	body = `{"error":{"errorCode":131149829,"httpStatusCode":401,"messages":[{"en-US":"Unauthorized"}]}}`
+	err = c.DoWithHeaders(context.Background(), http.MethodGet, "/api/instances/filesystem/fs_9", nil, nil, resp)
+	e, ok = err.(*types.Error)
+	if !ok || e.ErrorContent.HTTPStatusCode != http.StatusUnauthorized || e.ErrorContent.ErrorCode != 131149829 {
+		t.Fatalf("Unauthorized error body did not return an authentication error: %v", err)
+	}
+
+	body = `{"content":{"id":"fs_9","name":"error-fs"}}`
+	err = c.DoWithHeaders(context.Background(), http.MethodGet, "/api/instances/filesystem/fs_9", nil, nil, resp)
+	if err != nil || resp.Content.Name != "error-fs" {
+		t.Fatalf("Valid response after session expiry failed: %v", err)
+	}
+
+	fmt.Println("Session Expired Test Successful")
+}
+
+func exportSessionTest(t *testing.T) {
+	fmt.Println("Begin - Export Session Test")
+
+	var receivedToken, receivedCookie string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/loginSessionInfo" {
+			http.SetCookie(w, &http.Cookie{Name: "mod_sec_emc", Value: "session-2", Path: "/"})
+		}
+		receivedToken = r.Header.Get(HeaderEMCCSRFToken)
+		receivedCookie = ""
+		if cookie, err := r.Cookie("mod_sec_emc"); err == nil {
+			receivedCookie = cookie.Value
+		}
+		w.Header().Set(HeaderKeyContentType, HeaderValContentTypeJSON)
+		fmt.Fprint(w, `{"content":{"id":"fs_10","name":"session-fs"}}`)
+	}
+	c, server := newTestClient(t, handler)
+	defer server.Close()
+
+	resp := &testResource{}
+	if err := c.DoWithHeaders(context.Background(), http.MethodGet, "/api/loginSessionInfo", nil, nil, resp); err != nil {
+		t.Fatalf("Login request failed: %v", err)
+	}
+	c.SetToken("token-2")
+	data, err := c.ExportSession()
+	if err != nil {
+		t.Fatalf("Export session failed: %v", err)
+	}
+
+	imported, err := New(context.Background(), server.URL, ClientOptions{Insecure: true}, false)
+	if err != nil {
+		t.Fatalf("Create API client failed: %v", err)
+	}
+	if err = imported.ImportSession(data); err != nil {
+		t.Fatalf("Import session failed: %v", err)
+	}
+	err = imported.DoWithHeaders(context.Background(), http.MethodPost, "/api/instances/filesystem/fs_10/action/modify", nil, nil, resp)
+	if err != nil || receivedToken != "token-2" || receivedCookie != "session-2" {
+		t.Fatalf("Imported session not sent, token: %s cookie: %s error: %v", receivedToken, receivedCookie, err)
+	}
+
+	//Negative cases
+	session := exportedSession{}
+	if err = json.Unmarshal(data, &session); err != nil {
+		t.Fatalf("Exported session is not valid JSON: %v", err)
+	}
+	session.Created = time.Now().Add(-2 * SessionMaxAge)
+	stale, _ := json.Marshal(session)
+	if err = imported.ImportSession(stale); err != ErrSessionExpired {
+		t.Fatalf("Import of an expired session case - failed: %v", err)
+	}
+
+	other, otherServer := newTestClient(t, handler)
+	defer otherServer.Close()
+	if err = other.ImportSession(data); err == nil {
+		t.Fatalf("Import of the session of another host case - failed: %v", err)
+	}
+
+	c.ClearSession()
+	if _, err = c.ExportSession(); err == nil {
+		t.Fatalf("Export of a cleared session case - failed: %v", err)
+	}
+
+	fmt.Println("Export Session Test Successful")
+}
+
+func proxyTest(t *testing.T) {
+	fmt.Println("Begin - Proxy Test")
+
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.URL.Host
+		w.Header().Set(HeaderKeyContentType, HeaderValContentTypeJSON)
+		fmt.Fprint(w, `{"content":{"id":"fs_11","name":"proxied-fs"}}`)
+	}))
+	defer proxy.Close()
+
+	c, err := New(context.Background(), "http://array.example.com", ClientOptions{Insecure: true, Proxy: proxy.URL}, false)
+	if err != nil {
+		t.Fatalf("Create API client with proxy failed: %v", err)
+	}
+	resp := &testResource{}
+	err = c.DoWithHeaders(context.Background(), http.MethodGet, "/api/instances/filesystem/fs_11", nil, nil, resp)
+	if err != nil || proxiedHost != "array.example.com" || resp.Content.ID != "fs_11" {
+		t.Fatalf("Request not sent through the proxy, host: %s error: %v", proxiedHost, err)
+	}
+
+	//Negative case
+	_, err = New(context.Background(), "http://array.example.com", ClientOptions{Proxy: "proxy-without-scheme"}, false)
+	if err == nil {
+		t.Fatalf("Create API client with invalid proxy URL case - failed: %v", err)
+	}
+
+	fmt.Println("Proxy Test Successful")
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// SessionMaxAge is the age after which an exported session is considered expired and is not imported
+const SessionMaxAge = time.Hour
+
+// ErrSessionExpired is returned when importing a session older than SessionMaxAge
+var ErrSessionExpired = errors.New("session expired")
+
+// SessionStore holds the session of a client with the array: the EMC-CSRF-TOKEN sent with POST & DELETE requests and
+// the session cookies, used as the cookie jar of the HTTP client. A custom store can be injected with SetSessionStore
+// (Ex: to simulate an expired token or rotated cookies in tests).
+type SessionStore interface {
+	http.CookieJar
+
+	// Get returns the EMC-CSRF-TOKEN, empty if not authenticated
+	Get() string
+
+	// Set stores the EMC-CSRF-TOKEN
+	Set(token string)
+
+	// Clear drops the token and the session cookies
+	Clear()
+}
+
+// sessionStore is the default SessionStore, safe for concurrent use
+type sessionStore struct {
+	mu    sync.RWMutex
+	token string
+	jar   http.CookieJar
+}
+
+// NewSessionStore returns an empty session store
+func NewSessionStore() SessionStore {
+	jar, _ := cookiejar.New(nil)
+	return &sessionStore{jar: jar}
+}
+
+func (s *sessionStore) Get() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.token
+}
+
+func (s *sessionStore) Set(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+}
+
+func (s *sessionStore) Clear() {
+	jar, _ := cookiejar.New(nil)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = ""
+	s.jar = jar
+}
+
+func (s *sessionStore) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.jar.SetCookies(u, cookies)
+}
+
+func (s *sessionStore) Cookies(u *url.URL) []*http.Cookie {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.jar.Cookies(u)
+}
+
+// exportedSession is the persisted form of a session, see ExportSession
+type exportedSession struct {
+	Host    string         `json:"host"`
+	Token   string         `json:"token"`
+	Cookies []*http.Cookie `json:"cookies"`
+	Created time.Time      `json:"created"`
+}
+
+// ExportSession encodes the session of the client (token, cookies & creation time) for reuse by another process
+func (c *client) ExportSession() ([]byte, error) {
+	token := c.session.Get()
+	if token == "" {
+		return nil, errors.New("no session to export, the client is not authenticated")
+	}
+	hostURL, err := url.Parse(c.host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host %s: %v", c.host, err)
+	}
+	return json.Marshal(exportedSession{
+		Host:    c.host,
+		Token:   token,
+		Cookies: c.session.Cookies(hostURL),
+		Created: c.sessionCreated,
+	})
+}
+
+// ImportSession restores a session exported by ExportSession for the same host.
+// ErrSessionExpired is returned, and the current session kept, if the session is older than SessionMaxAge.
+func (c *client) ImportSession(data []byte) error {
+	session := exportedSession{}
+	if err := json.Unmarshal(data, &session); err != nil {
+		return fmt.Errorf("invalid session: %v", err)
+	}
+	if session.Host != c.host {
+		return fmt.Errorf("session of host %s can't be imported for host %s", session.Host, c.host)
+	}
+	if session.Token == "" {
+		return errors.New("invalid session: empty token")
+	}
+	if time.Since(session.Created) > SessionMaxAge {
+		return ErrSessionExpired
+	}
+	hostURL, err := url.Parse(c.host)
+	if err != nil {
+		return fmt.Errorf("invalid host %s: %v", c.host, err)
+	}
+	c.session.Clear()
+	c.session.SetCookies(hostURL, session.Cookies)
+	c.session.Set(session.Token)
+	c.sessionCreated = session.Created
+	return nil
+}
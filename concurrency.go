@@ -0,0 +1,49 @@
+package gounity
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+//requestLimiter caps the number of requests in flight to the array, the excess requests wait for a free slot
+type requestLimiter struct {
+	mutex sync.RWMutex
+	slots chan struct{}
+}
+
+//SetMaxConcurrentRequests limits the number of simultaneous requests the client sends to the array to n, to avoid
+//overwhelming a small array during bulk operations. The excess requests are queued until a request completes or their
+//context is done. A value of 0 or less removes the limit. The requests already in flight are not affected.
+func (c *Client) SetMaxConcurrentRequests(n int) {
+	c.limiter.mutex.Lock()
+	defer c.limiter.mutex.Unlock()
+	if n <= 0 {
+		c.limiter.slots = nil
+		return
+	}
+	c.limiter.slots = make(chan struct{}, n)
+}
+
+//capacity returns the maximum number of requests in flight, 0 when not limited
+func (l *requestLimiter) capacity() int {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return cap(l.slots)
+}
+
+//acquire waits for a free request slot and returns the function releasing it
+func (l *requestLimiter) acquire(ctx context.Context) (func(), error) {
+	l.mutex.RLock()
+	slots := l.slots
+	l.mutex.RUnlock()
+	if slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for a free request slot failed. Error: %v", ctx.Err())
+	}
+}
@@ -0,0 +1,76 @@
+package gounity
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dell/gounity/api"
+	"github.com/dell/gounity/types"
+)
+
+func TestConcurrency(t *testing.T) {
+	ctx = context.Background()
+
+	maxConcurrentRequestsTest(t)
+}
+
+func maxConcurrentRequestsTest(t *testing.T) {
+	fmt.Println("Begin - Max Concurrent Requests Test")
+
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set(api.HeaderKeyContentType, api.HeaderValContentTypeJSON)
+		fmt.Fprint(w, `{"content":{"id":"pool_1"}}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(ctx, server.URL, true)
+	if err != nil {
+		t.Fatalf("Create client failed: %v", err)
+	}
+	client.SetMaxConcurrentRequests(2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pool := &types.StoragePool{}
+			if err := client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceURI, api.PoolAction, "pool_1"), nil, pool); err != nil {
+				t.Errorf("Request failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if maxInFlight > 2 {
+		t.Fatalf("Max concurrent requests not honored, %d requests in flight", maxInFlight)
+	}
+
+	//Negative case
+	client.SetMaxConcurrentRequests(1)
+	release, _ := client.limiter.acquire(ctx)
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	err = client.executeWithRetryAuthenticate(timeoutCtx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceURI, api.PoolAction, "pool_1"), nil, &types.StoragePool{})
+	release()
+	if err == nil {
+		t.Fatalf("Request waiting for a free slot past the context deadline case - failed: %v", err)
+	}
+
+	fmt.Println("Max Concurrent Requests Test - Successful")
+}
@@ -1,26 +1,37 @@
 package gounity
 
+import "strings"
+
 const (
 	//LunDisplayFields to display the Volume fields
 	LunDisplayFields = "id,name,description,type,wwn,sizeTotal,sizeUsed,sizeAllocated,hostAccess,pool,tieringPolicy,ioLimitPolicy,isThinEnabled,isDataReductionEnabled,isThinClone,parentSnap,originalParentLun?fields,health"
 
 	//FileSystemDisplayFields to display the File System fields
-	FileSystemDisplayFields = "id,name,description,type,sizeTotal,isThinEnabled,isDataReductionEnabled,pool,nasServer,storageResource,nfsShare?fields,cifsShare,tieringPolicy,hostIOSize,health"
+	FileSystemDisplayFields = "id,name,description,type,sizeTotal,sizeUsed,isThinEnabled,isDataReductionEnabled,pool,nasServer,storageResource,nfsShare?fields,cifsShare,tieringPolicy,hostIOSize,fileEventSettings,health"
 
 	//StorageResourceDisplayFields to display Storage Resource fields
 	StorageResourceDisplayFields = "id,name,filesystem"
 
+	//StorageResourceDetailDisplayFields to display Storage Resource fields with the type, size, pools and child resources
+	StorageResourceDetailDisplayFields = "id,name,description,type,sizeTotal,sizeUsed,sizeAllocated,pools,filesystem,luns,health"
+
+	//StorageResourceDataReductionFields to display Storage Resource data reduction savings fields
+	StorageResourceDataReductionFields = "id,dataReductionSizeSaved,dataReductionPercent,dataReductionRatio"
+
 	//TenantDisplayFields to display Tenants fields
 	TenantDisplayFields = "id,name"
 
 	//NFSShareDisplayfields to display the NFS Share fields
-	NFSShareDisplayfields = "id,name,filesystem,readOnlyHosts,readWriteHosts,readOnlyRootAccessHosts,rootAccessHosts,exportPaths"
+	NFSShareDisplayfields = "id,name,filesystem,snap,isReadOnly,path,defaultAccess,readOnlyHosts,readWriteHosts,readOnlyRootAccessHosts,rootAccessHosts,noAccessHosts,exportPaths,minSecurity,nfsOwnerUsername"
 
 	//NasServerDisplayfields to display the NAS Server fields
-	NasServerDisplayfields = "id,name,nfsServer?fields"
+	NasServerDisplayfields = "id,name,nfsServer?fields,cifsServer,homeSP,currentSP,fileDNSServer,fileNISServer"
+
+	//NFSServerSecurityFields to display the NFS Server secure NFS fields
+	NFSServerSecurityFields = "id,isSecureEnabled,kdcType"
 
 	//SnapshotDisplayFields to display the Snapshot fields
-	SnapshotDisplayFields = "id,name,description,storageResource?,lun,creationTime,expirationTime,lastRefreshTime,state,size,isAutoDelete,accessType,parentSnap"
+	SnapshotDisplayFields = "id,name,description,storageResource?,lun,creationTime,expirationTime,lastRefreshTime,state,size,isAutoDelete,accessType,parentSnap,snapGroup,hostAccess"
 
 	//HostInitiatorsDisplayFields to display the HostInitiator fields
 	HostInitiatorsDisplayFields = "id,health,type,initiatorId,isIgnored,parentHost,paths"
@@ -31,6 +42,9 @@ const (
 	//LicenseInfoDisplayFields to display License Info fields
 	LicenseInfoDisplayFields = "isInstalled,isValid"
 
+	//LicenseExpiryDisplayFields to display License Info fields with the expiry
+	LicenseExpiryDisplayFields = "isInstalled,isValid,expires,isPermanent"
+
 	//HostInitiatorPathDisplayFields to display the HostInitiatorPath fields
 	HostInitiatorPathDisplayFields = "fcPort"
 
@@ -46,6 +60,42 @@ const (
 	//HostfieldsToQuery to display host fields
 	HostfieldsToQuery = "id,name,description,fcHostInitiators,iscsiHostInitiators,hostIPPorts?fields"
 
+	//HostListDisplayFields to display host fields with the IP ports and initiators details
+	HostListDisplayFields = "id,name,description,fcHostInitiators,iscsiHostInitiators,hostIPPorts.id,hostIPPorts.address"
+
 	//StoragePoolFields to display Storage Pool fields
-	StoragePoolFields = "id,name,description,sizeFree,sizeTotal,sizeUsed,sizeSubscribed,hasDataReductionEnabledLuns,hasDataReductionEnabledFs,isFASTCacheEnabled,type,isAllFlash,poolFastVP"
+	StoragePoolFields = "id,name,description,sizeFree,sizeTotal,sizeUsed,sizeSubscribed,hasDataReductionEnabledLuns,hasDataReductionEnabledFs,isFASTCacheEnabled,type,isAllFlash,poolFastVP,health"
+
+	//StoragePoolThresholdFields to display Storage Pool alert and space harvesting threshold fields
+	StoragePoolThresholdFields = "id,alertThreshold,isHarvestEnabled,poolSpaceHarvestHighThreshold,poolSpaceHarvestLowThreshold"
+
+	//StoragePoolTierFields to display Storage Pool tier fields
+	StoragePoolTierFields = "id,tiers"
+
+	//ReplicationSessionDisplayFields to display Replication Session fields
+	ReplicationSessionDisplayFields = "id,name,replicationResourceType,status,health,networkStatus,syncState,syncProgress,srcResourceId,dstResourceId,remoteSystem,lastSyncTime"
+
+	//RemoteSystemDisplayFields to display Remote System fields
+	RemoteSystemDisplayFields = "id,name,model,serialNumber,managementAddress,health"
+
+	//RouteDisplayFields to display Route fields
+	RouteDisplayFields = "id,ipInterface,destination,netmask,v6PrefixLength,gateway"
+
+	//FileInterfaceDisplayFields to display File Interface fields
+	FileInterfaceDisplayFields = "id,name,nasServer,ipPort,ipAddress,netmask,v6PrefixLength,gateway,role"
+
+	//QuotaLimitFields to display tree and user quota limit fields
+	QuotaLimitFields = "id,hardLimit,softLimit"
+
+	//AlertDisplayFields to display Alert fields
+	AlertDisplayFields = "id,timestamp,severity,component,messageId,message,description,resolution,isAcknowledged,state"
 )
+
+//displayFields returns the fields requested by the caller of a finder joined for the query, the default fields if none
+//are requested. Requesting only the needed fields (Ex: id,name for an existence check) makes the lookup cheaper.
+func displayFields(defaultFields string, fields []string) string {
+	if len(fields) == 0 {
+		return defaultFields
+	}
+	return strings.Join(fields, ",")
+}
@@ -7,11 +7,14 @@ package gounity
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/dell/gounity/util"
 
@@ -19,9 +22,78 @@ import (
 	"github.com/dell/gounity/types"
 )
 
-//Filesystem structure
-type Filesystem struct {
+//Filesystem interface defines the filesystem and NFS share operations supported on the array
+type Filesystem interface {
+	FindFilesystemByName(ctx context.Context, filesystemName string, fields ...string) (*types.Filesystem, error)
+	FindFilesystemByID(ctx context.Context, filesystemID string, fields ...string) (*types.Filesystem, error)
+	ResolveFilesystem(ctx context.Context, nameOrID string) (*types.Filesystem, error)
+	FilesystemExists(ctx context.Context, nameOrID string) (bool, error)
+	GetFilesystemIDFromResID(ctx context.Context, filesystemResID string) (string, error)
+	GetFilesystemIdentity(ctx context.Context, filesystemResID string) (*types.FilesystemIdentity, error)
+	CreateFilesystem(ctx context.Context, name, storagepool, description, nasServer string, size uint64, tieringPolicy, hostIOSize, supportedProtocol int, isThinEnabled, isDataReductionEnabled bool) (*types.Filesystem, error)
+	CreateFilesystemWithFileEventSettings(ctx context.Context, name, storagepool, description, nasServer string, size uint64, tieringPolicy, hostIOSize, supportedProtocol int, isThinEnabled, isDataReductionEnabled bool, fileEventSettings types.FileEventSettings) (*types.Filesystem, error)
+	CreateFilesystemOnSP(ctx context.Context, name, storagepool, description, nasServer string, size uint64, tieringPolicy, hostIOSize, supportedProtocol int, isThinEnabled, isDataReductionEnabled bool, spID string) (*types.Filesystem, error)
+	GetFilesystemMountTarget(ctx context.Context, filesystemID string) (string, error)
+	GetFilesystemCurrentSP(ctx context.Context, filesystemID string) (string, error)
+	ModifyFilesystemEventSettings(ctx context.Context, filesystemID string, fileEventSettings types.FileEventSettings) error
+	EnableCIFSOnFilesystem(ctx context.Context, filesystemID string) error
+	SetFilesystemSnapAutoDeletePolicy(ctx context.Context, filesystemID string, poolFullPolicy, spaceUsedPolicy int) error
+	DeleteFilesystem(ctx context.Context, filesystemID string) error
+	DeleteFilesystemAndWait(ctx context.Context, filesystemID string, pollInterval time.Duration) error
+	ExpandFilesystem(ctx context.Context, filesystemID string, newSize uint64) error
+	SetFilesystemAutoExtend(ctx context.Context, filesystemID string, maxSize uint64, highWatermark int) error
+	ApplyFilesystemAutoExtend(ctx context.Context, filesystemID string) error
+	ModifyFilesystemThinProvisioning(ctx context.Context, filesystemID string, isThinEnabled bool) error
+	ModifyFilesystemDescription(ctx context.Context, filesystemID, description string) error
+	ModifyFilesystemName(ctx context.Context, filesystemID, newName string) error
+	GetFilesystemDescription(ctx context.Context, filesystemID string) (string, error)
+	SetFilesystemTags(ctx context.Context, filesystemID string, tags map[string]string) error
+	GetFilesystemTags(ctx context.Context, filesystemID string) (map[string]string, error)
+	GetFilesystemTieringPolicy(ctx context.Context, filesystemID string) (int, error)
+	BulkSetTieringPolicy(ctx context.Context, filesystemIDs []string, tieringPolicy int) ([]types.ModifyResult, error)
+	GetFilesystemSnapshotStats(ctx context.Context, filesystemID string) (int, uint64, error)
+	GetFilesystemDataReductionStats(ctx context.Context, filesystemID string) (*types.DataReductionStats, error)
+	CreateNFSShare(ctx context.Context, name, path, filesystemID string, nfsShareDefaultAccess NFSShareDefaultAccess) (*types.Filesystem, error)
+	CreateNFSShareWithKerberos(ctx context.Context, name, path, filesystemID string, nfsShareDefaultAccess NFSShareDefaultAccess, minSecurity NFSShareSecurity, nfsOwnerUsername string) (*types.Filesystem, error)
+	CreateNFSShareFromSnapshot(ctx context.Context, name, path, snapshotID string, nfsShareDefaultAccess NFSShareDefaultAccess) (*types.NFSShare, error)
+	CreateNFSShareFromSnapshotWhenReady(ctx context.Context, name, path, snapshotID string, nfsShareDefaultAccess NFSShareDefaultAccess, pollInterval time.Duration) (*types.NFSShare, error)
+	CreateNFSShareFromLatestSnapshot(ctx context.Context, name, path, filesystemID string, nfsShareDefaultAccess NFSShareDefaultAccess) (*types.NFSShare, error)
+	CopyNFSShareConfig(ctx context.Context, srcNFSShareID, dstFilesystemID string) (*types.NFSShare, error)
+	RehomeNFSShare(ctx context.Context, nfsShareID, targetFilesystemID string) (*types.NFSShare, error)
+	FindNFSShareByName(ctx context.Context, nfsSharename string, fields ...string) (*types.NFSShare, error)
+	FindNFSShareByID(ctx context.Context, nfsShareID string, fields ...string) (*types.NFSShare, error)
+	FindNFSShareByNameAndFilesystem(ctx context.Context, nfsShareName, filesystemID string) (*types.NFSShare, error)
+	FindNFSShareByPath(ctx context.Context, filesystemID, path string) (*types.NFSShare, error)
+	NFSShareExists(ctx context.Context, nameOrID string) (bool, error)
+	ListFilesystems(ctx context.Context, nextToken string, maxEntries int) ([]types.Filesystem, string, error)
+	ListNFSShares(ctx context.Context, nextToken string, maxEntries int) ([]types.NFSShare, string, error)
+	ListNFSSharesForHost(ctx context.Context, hostID, nextToken string, maxEntries int) ([]types.NFSShare, string, error)
+	GetEffectiveHostAccess(ctx context.Context, nfsShareID, hostID string) (AccessType, error)
+	ModifyNFSShareHostAccess(ctx context.Context, filesystemID, nfsShareID string, hostIDs []string, accessType AccessType) error
+	ModifyNFSShareHostAccessWithMode(ctx context.Context, filesystemID, nfsShareID string, hostIDs []string, accessType AccessType, mode HostAccessMode) error
+	ReconcileNFSShareAccess(ctx context.Context, filesystemID, nfsShareID string, desired types.NFSShareAccessSpec) error
+	RemoveNFSShareHostAccess(ctx context.Context, filesystemID, nfsShareID string, hostIDs []string, accessType AccessType) error
+	ModifyFilesystemNFSDefaults(ctx context.Context, filesystemID string, defaultAccess NFSShareDefaultAccess, rootSquash bool) error
+	GrantClusterAccess(ctx context.Context, filesystemID, nfsShareID string, hostSpecs []types.HostSpec, accessType AccessType) error
+	ModifyNFSShareCreatedFromSnapshotHostAccess(ctx context.Context, nfsShareID string, hostIDs []string, accessType AccessType) error
+	ModifySnapshotNFSShareHostAccess(ctx context.Context, snapshotID, nfsShareID string, hostIDs []string, accessType AccessType) error
+	PromoteSnapshotShareToReadWrite(ctx context.Context, nfsShareID string) error
+	DeleteNFSShare(ctx context.Context, filesystemID, nfsShareID string) error
+	DeleteNFSShareCreatedFromSnapshot(ctx context.Context, nfsShareID string) error
+	DeleteAllNFSShares(ctx context.Context, filesystemID string) error
+	FindNASServerByID(ctx context.Context, nasServerID string) (*types.NASServer, error)
+	DeleteNASServer(ctx context.Context, nasServerID string, force, deleteFilesystems bool) error
+	SetNASServerDNS(ctx context.Context, nasServerID, domain string, addresses []string) error
+	SetNASServerNIS(ctx context.Context, nasServerID, domain string, addresses []string) error
+	SetSkipVerificationRead(skip bool)
+}
+
+//filesystem structure implements the Filesystem interface
+type filesystem struct {
 	client *Client
+
+	//skipVerificationRead disables the read of the NFS share after it is created, modified or deleted
+	skipVerificationRead bool
 }
 
 //FsNameMaxLength provides the allowed max length for filesystem name
@@ -29,6 +101,29 @@ const (
 	FsNameMaxLength = 63
 )
 
+//Host IO size constants, the hostIOSize values the array accepts for a filesystem
+const (
+	HostIOSizeGeneral8K     = 8192
+	HostIOSizeGeneral16K    = 16384
+	HostIOSizeGeneral32K    = 32768
+	HostIOSizeGeneral64K    = 65536
+	HostIOSizeExchange2007  = 8193
+	HostIOSizeExchange2010  = 8194
+	HostIOSizeExchange2013  = 8195
+	HostIOSizeOracle        = 8196
+	HostIOSizeSQLServer     = 8197
+	HostIOSizeVMwareHorizon = 8198
+	HostIOSizeSharePoint    = 8199
+	HostIOSizeSAP           = 8200
+)
+
+//validHostIOSizes lists the hostIOSize values in the order they are reported in the validation error
+var validHostIOSizes = []int{
+	HostIOSizeGeneral8K, HostIOSizeGeneral16K, HostIOSizeGeneral32K, HostIOSizeGeneral64K,
+	HostIOSizeExchange2007, HostIOSizeExchange2010, HostIOSizeExchange2013, HostIOSizeOracle,
+	HostIOSizeSQLServer, HostIOSizeVMwareHorizon, HostIOSizeSharePoint, HostIOSizeSAP,
+}
+
 //AccessType type is string
 type AccessType string
 
@@ -38,6 +133,16 @@ const (
 	ReadWriteAccessType     = AccessType("READ_WRITE")
 	ReadOnlyRootAccessType  = AccessType("READ_ONLY_ROOT")
 	ReadWriteRootAccessType = AccessType("READ_WRITE_ROOT")
+	NoAccessType            = AccessType("NO_ACCESS") //Deny list, applies even when the default access grants access
+)
+
+//HostAccessMode type is string
+type HostAccessMode string
+
+//HostAccessMode constants
+const (
+	ReplaceHostAccessMode = HostAccessMode("REPLACE") //Overwrite the access list with exactly the given hosts
+	AppendHostAccessMode  = HostAccessMode("APPEND")  //Merge the given hosts into the existing access list
 )
 
 //NFSShareDefaultAccess is string
@@ -52,30 +157,132 @@ const (
 	ReadWriteRootDefaultAccess = NFSShareDefaultAccess("4")
 )
 
+//NFSShareSecurity is the minimum security the NFS clients must use to access a NFS share
+type NFSShareSecurity int
+
+//NFSShareSecurity constants
+const (
+	SysSecurity                    NFSShareSecurity = 0 //AUTH_SYS, the default
+	KerberosSecurity               NFSShareSecurity = 1
+	KerberosWithIntegritySecurity  NFSShareSecurity = 2
+	KerberosWithEncryptionSecurity NFSShareSecurity = 3
+)
+
+//KDC type constants of a NFS server with secure NFS enabled
+const (
+	KdcTypeCustom  = 0
+	KdcTypeUnix    = 1
+	KdcTypeWindows = 2 //KDC of the AD domain the SMB server of the NAS server is joined to
+)
+
+//DeleteFilesystemWaitTimeout is the maximum time DeleteFilesystemAndWait waits for the filesystem to disappear
+const DeleteFilesystemWaitTimeout = 5 * time.Minute
+
+//Auto extend policy tags, stored with the filesystem tags
+const (
+	AutoExtendMaxSizeTag       = "gounity.autoExtend.maxSize"
+	AutoExtendHighWatermarkTag = "gounity.autoExtend.highWatermark"
+)
+
+//FAST VP tiering policy constants
+const (
+	TieringPolicyAutotierHigh   = 0
+	TieringPolicyAutotier       = 1
+	TieringPolicyHighest        = 2
+	TieringPolicyLowest         = 3
+	TieringPolicyNoDataMovement = 4
+	TieringPolicyMixed          = 5 //Reported for resources with mixed policies, cannot be set
+)
+
+//BulkModifyMaxParallel is the maximum number of modify requests a bulk modify sends concurrently
+const BulkModifyMaxParallel = 4
+
+//Storage processor Id constants
+const (
+	SPA = "spa"
+	SPB = "spb"
+)
+
+//Snapshot auto delete policy constants
+const (
+	SnapAutoDeletePolicyDisabled = 0 //Snapshots are never deleted automatically
+	SnapAutoDeletePolicyEnabled  = 1 //Snapshots are deleted automatically once the watermark is reached
+)
+
 //ErrorFilesystemNotFound stores error for filesystem not found
 var ErrorFilesystemNotFound = errors.New("Unable to find filesystem")
 
 //FilesystemNotFoundErrorCode stores error code for filesystem not found
 var FilesystemNotFoundErrorCode = "0x7d13005"
 
+//ErrorNFSShareNotFound stores error for NFS share not found
+var ErrorNFSShareNotFound = errors.New("Unable to find NFS share")
+
+//NFSShareNotFoundErrorCode stores error code for NFS share not found
+var NFSShareNotFoundErrorCode = "0x7d13005"
+
+//ErrNFSServerNotConfigured stores error for NAS server without an enabled NFS server
+var ErrNFSServerNotConfigured = errors.New("NFS server is not configured on the NAS server")
+
+//ErrKerberosNotConfigured stores error for NAS server without Kerberos configured for secure NFS
+var ErrKerberosNotConfigured = errors.New("Kerberos is not configured on the NAS server")
+
+//ErrThinConversionNotSupported stores error for converting a filesystem between thin and thick provisioning
+var ErrThinConversionNotSupported = errors.New("converting a filesystem between thin and thick provisioning is not supported")
+
+//ErrSPMismatch stores error for a NAS server not running on the storage processor requested for a filesystem
+var ErrSPMismatch = errors.New("NAS server is not running on the requested storage processor")
+
+//ErrorMultipleNFSSharesWithPath stores error for a NFS share looked up by a path shared by several NFS shares
+var ErrorMultipleNFSSharesWithPath = errors.New("multiple NFS shares found with the path")
+
+//ErrorNFSShareConflict stores error for a NFS share existing with the same name but a different configuration
+var ErrorNFSShareConflict = errors.New("NFS share already exists with a different configuration")
+
+//ErrRehomeToSameNASServer stores error for rehoming a NFS share to a filesystem of the NAS server of the NFS share
+var ErrRehomeToSameNASServer = errors.New("NFS share names are unique per NAS server, the target filesystem should be on another NAS server")
+
+//ErrNASServerDeletionBlocked stores error for a NAS server which cannot be deleted because of it's dependent resources
+var ErrNASServerDeletionBlocked = errors.New("NAS server deletion is blocked by it's dependent resources")
+
+//ErrorFilesystemNameConflict stores error for a filesystem renamed to the name of another filesystem
+var ErrorFilesystemNameConflict = errors.New("another filesystem already exists with the name")
+
+//ErrorSnapshotNotWritable stores error for NFS share promotion on a read-only snapshot
+var ErrorSnapshotNotWritable = errors.New("snapshot is read-only, a writable snapshot (thin clone) is required")
+
 //AttachedSnapshotsErrorCode stores error code for attached snapshots
 var AttachedSnapshotsErrorCode = "0x6000c17"
 
+//DefaultFileEventSettings stores the file event settings used when creating a filesystem without explicit settings
+var DefaultFileEventSettings = types.FileEventSettings{
+	IsCIFSEnabled: false, //Set to false to disable CIFS event publishing
+	IsNFSEnabled:  true,  //Set to true to enable NFS event publishing alone
+}
+
 //MarkFilesystemForDeletion stores filesystem for deletion mark
 var MarkFilesystemForDeletion = "csi-marked-filesystem-for-deletion(do not remove this from description)"
 
+//ErrFilesystemMarkedForDeletion is returned when a filesystem having snapshots is marked for deletion instead of being
+//deleted, it is deleted along with it's last snapshot (see DeleteFilesystemAsSnapshot)
+var ErrFilesystemMarkedForDeletion = errors.New("filesystem has snapshots, it is marked for deletion")
+
+//FilesystemTagsMarker separates the human readable description of a filesystem from it's JSON encoded tags
+var FilesystemTagsMarker = "gounity-tags:"
+
 //NewFilesystem function returns filesystem
-func NewFilesystem(client *Client) *Filesystem {
-	return &Filesystem{client}
+func NewFilesystem(client *Client) Filesystem {
+	return &filesystem{client: client}
 }
 
 //FindFilesystemByName - Find the Filesystem by it's name. If the Filesystem is not found, an error will be returned.
-func (f *Filesystem) FindFilesystemByName(ctx context.Context, filesystemName string) (*types.Filesystem, error) {
+//Only the given fields are fetched when any, FileSystemDisplayFields otherwise.
+func (f *filesystem) FindFilesystemByName(ctx context.Context, filesystemName string, fields ...string) (*types.Filesystem, error) {
 	if len(filesystemName) == 0 {
 		return nil, errors.New("Filesystem Name shouldn't be empty")
 	}
 	fileSystemResp := &types.Filesystem{}
-	err := f.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceByNameWithFieldsURI, api.FileSystemAction, filesystemName, FileSystemDisplayFields), nil, fileSystemResp)
+	err := f.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceByNameWithFieldsURI, api.FileSystemAction, filesystemName, displayFields(FileSystemDisplayFields, fields)), nil, fileSystemResp)
 	if err != nil {
 		if strings.Contains(err.Error(), FilesystemNotFoundErrorCode) {
 			return nil, ErrorFilesystemNotFound
@@ -86,13 +293,14 @@ func (f *Filesystem) FindFilesystemByName(ctx context.Context, filesystemName st
 }
 
 //FindFilesystemByID - Find the Filesystem by it's Id. If the Filesystem is not found, an error will be returned.
-func (f *Filesystem) FindFilesystemByID(ctx context.Context, filesystemID string) (*types.Filesystem, error) {
-	log := util.GetRunIDLogger(ctx)
+//Only the given fields are fetched when any, FileSystemDisplayFields otherwise.
+func (f *filesystem) FindFilesystemByID(ctx context.Context, filesystemID string, fields ...string) (*types.Filesystem, error) {
+	log := f.client.getLogger(ctx)
 	if len(filesystemID) == 0 {
 		return nil, errors.New("Filesystem Id shouldn't be empty")
 	}
 	fileSystemResp := &types.Filesystem{}
-	err := f.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceWithFieldsURI, api.FileSystemAction, filesystemID, FileSystemDisplayFields), nil, fileSystemResp)
+	err := f.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceWithFieldsURI, api.FileSystemAction, filesystemID, displayFields(FileSystemDisplayFields, fields)), nil, fileSystemResp)
 	if err != nil {
 		log.Debugf("Unable to find filesystem Id %s Error: %v", filesystemID, err)
 		if strings.Contains(err.Error(), FilesystemNotFoundErrorCode) {
@@ -103,8 +311,30 @@ func (f *Filesystem) FindFilesystemByID(ctx context.Context, filesystemID string
 	return fileSystemResp, nil
 }
 
+//ResolveFilesystem - Find the Filesystem by either it's Id or it's name. The value is looked up as an Id first
+//and as a name if no filesystem with that Id exists.
+func (f *filesystem) ResolveFilesystem(ctx context.Context, nameOrID string) (*types.Filesystem, error) {
+	if len(nameOrID) == 0 {
+		return nil, errors.New("Filesystem name or Id shouldn't be empty")
+	}
+
+	filesystemResp, err := f.FindFilesystemByID(ctx, nameOrID)
+	if err == nil {
+		return filesystemResp, nil
+	}
+	if err != ErrorFilesystemNotFound {
+		return nil, err
+	}
+	return f.FindFilesystemByName(ctx, nameOrID)
+}
+
+//FilesystemExists - Check whether a Filesystem exists with the given Id or name, without fetching the filesystem
+func (f *filesystem) FilesystemExists(ctx context.Context, nameOrID string) (bool, error) {
+	return f.client.resourceExists(ctx, api.FileSystemAction, nameOrID)
+}
+
 //GetFilesystemIDFromResID - Returns the filesystem ID for the filesystem
-func (f *Filesystem) GetFilesystemIDFromResID(ctx context.Context, filesystemResID string) (string, error) {
+func (f *filesystem) GetFilesystemIDFromResID(ctx context.Context, filesystemResID string) (string, error) {
 	if filesystemResID == "" {
 		return "", errors.New("Filesystem Resource Id shouldn't be empty")
 	}
@@ -117,33 +347,41 @@ func (f *Filesystem) GetFilesystemIDFromResID(ctx context.Context, filesystemRes
 	return fileSystemResp.StorageResourceContent.Filesystem.ID, nil
 }
 
-//CreateFilesystem - Create a new filesystem on the array
-func (f *Filesystem) CreateFilesystem(ctx context.Context, name, storagepool, description, nasServer string, size uint64, tieringPolicy, hostIOSize, supportedProtocol int, isThinEnabled, isDataReductionEnabled bool) (*types.Filesystem, error) {
-	log := util.GetRunIDLogger(ctx)
-	if name == "" {
-		return nil, errors.New("filesystem name should not be empty")
+//GetFilesystemIdentity - Returns the array assigned identifiers of the filesystem for the given storage resource Id.
+//The storage resource Id is the only identifier returned by CreateFilesystem (FileContent.StorageResource.ID).
+func (f *filesystem) GetFilesystemIdentity(ctx context.Context, filesystemResID string) (*types.FilesystemIdentity, error) {
+	if filesystemResID == "" {
+		return nil, errors.New("Filesystem Resource Id shouldn't be empty")
 	}
 
-	if len(name) > FsNameMaxLength {
-		return nil, fmt.Errorf("filesystem name %s should not exceed %d characters", name, FsNameMaxLength)
+	storageResourceResp := &types.StorageResourceParameters{}
+	err := f.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceWithFieldsURI, api.StorageResourceAction, filesystemResID, StorageResourceDisplayFields), nil, storageResourceResp)
+	if err != nil {
+		return nil, fmt.Errorf("get filesystem identity for %s failed with error: %v", filesystemResID, err)
+	}
+	if storageResourceResp.StorageResourceContent.Filesystem.ID == "" {
+		return nil, fmt.Errorf("storage resource %s is not a filesystem", filesystemResID)
 	}
 
-	poolAPI := NewStoragePool(f.client)
-	pool, err := poolAPI.FindStoragePoolByID(ctx, storagepool)
+	return &types.FilesystemIdentity{
+		ID:                storageResourceResp.StorageResourceContent.Filesystem.ID,
+		Name:              storageResourceResp.StorageResourceContent.Name,
+		StorageResourceID: storageResourceResp.StorageResourceContent.ID,
+	}, nil
+}
 
-	if err != nil {
-		return nil, fmt.Errorf("unable to get PoolID (%s) Error:%v", storagepool, err)
-	}
+//CreateFilesystem - Create a new filesystem on the array with the default file event settings
+func (f *filesystem) CreateFilesystem(ctx context.Context, name, storagepool, description, nasServer string, size uint64, tieringPolicy, hostIOSize, supportedProtocol int, isThinEnabled, isDataReductionEnabled bool) (*types.Filesystem, error) {
+	return f.CreateFilesystemWithFileEventSettings(ctx, name, storagepool, description, nasServer, size, tieringPolicy, hostIOSize, supportedProtocol, isThinEnabled, isDataReductionEnabled, DefaultFileEventSettings)
+}
 
+//CreateFilesystemWithFileEventSettings - Create a new filesystem on the array with the given file event (CEPA) publishing settings
+func (f *filesystem) CreateFilesystemWithFileEventSettings(ctx context.Context, name, storagepool, description, nasServer string, size uint64, tieringPolicy, hostIOSize, supportedProtocol int, isThinEnabled, isDataReductionEnabled bool, fileEventSettings types.FileEventSettings) (*types.Filesystem, error) {
+	log := f.client.getLogger(ctx)
 	storagePool := types.StoragePoolID{
 		PoolID: storagepool,
 	}
 
-	fileEventSettings := types.FileEventSettings{
-		IsCIFSEnabled: false, //Set to false to disable CIFS
-		IsNFSEnabled:  true,  //Set to true to enable NFS alone
-	}
-
 	nas := types.NasServerID{
 		NasServerID: nasServer,
 	}
@@ -157,6 +395,28 @@ func (f *Filesystem) CreateFilesystem(ctx context.Context, name, storagepool, de
 		FileEventSettings: fileEventSettings,
 	}
 
+	fileReqParam := types.FsCreateParam{
+		Name:         name,
+		Description:  description,
+		FsParameters: &fsParams,
+	}
+	validationErr := validateFsCreateParam(&fileReqParam)
+	if err := validationErr.errorOrNil(); err != nil {
+		return nil, err
+	}
+
+	poolAPI := NewStoragePool(f.client)
+	pool, err := poolAPI.FindStoragePoolByID(ctx, storagepool)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to get PoolID (%s) Error:%v", storagepool, err)
+	}
+
+	//the problems found with the pool and the licenses are reported together
+	if canHost, reason := poolCanHostFilesystem(pool, size, isThinEnabled); !canHost {
+		validationErr.add("fsParameters.size", "unable to create filesystem %s: %s", name, reason)
+	}
+
 	volAPI := NewVolume(f.client)
 	thinProvisioningLicenseInfoResp, err := volAPI.isFeatureLicensed(ctx, ThinProvisioning)
 	if err != nil {
@@ -171,13 +431,13 @@ func (f *Filesystem) CreateFilesystem(ctx context.Context, name, storagepool, de
 	if thinProvisioningLicenseInfoResp.LicenseInfoContent.IsInstalled && thinProvisioningLicenseInfoResp.LicenseInfoContent.IsValid {
 		fsParams.IsThinEnabled = strconv.FormatBool(isThinEnabled)
 	} else if isThinEnabled == true {
-		return nil, fmt.Errorf("thin provisioning is not supported on array and hence cannot create Filesystem")
+		validationErr.add("fsParameters.isThinEnabled", "thin provisioning is not supported on array and hence cannot create Filesystem")
 	}
 
 	if dataReductionLicenseInfoResp.LicenseInfoContent.IsInstalled && dataReductionLicenseInfoResp.LicenseInfoContent.IsValid {
 		fsParams.IsDataReductionEnabled = strconv.FormatBool(isDataReductionEnabled)
 	} else if isDataReductionEnabled == true {
-		return nil, fmt.Errorf("data reduction is not supported on array and hence cannot create Filesystem")
+		validationErr.add("fsParameters.isDataReductionEnabled", "data reduction is not supported on array and hence cannot create Filesystem")
 	}
 
 	if pool != nil && pool.StoragePoolContent.PoolFastVP.Status != 0 {
@@ -189,19 +449,23 @@ func (f *Filesystem) CreateFilesystem(ctx context.Context, name, storagepool, de
 	} else {
 		log.Debug("FastVP is not enabled")
 		if tieringPolicy != 0 {
-			return nil, fmt.Errorf("fastVP is not enabled and requested tiering policy is: %d ", tieringPolicy)
+			validationErr.add("fsParameters.fastVPParameters.tieringPolicy", "fastVP is not enabled and requested tiering policy is: %d", tieringPolicy)
 		}
 	}
-
-	fileReqParam := types.FsCreateParam{
-		Name:         name,
-		Description:  description,
-		FsParameters: &fsParams,
+	if err := validationErr.errorOrNil(); err != nil {
+		return nil, err
 	}
 
 	fileResp := &types.Filesystem{}
 	err = f.client.executeWithRetryAuthenticate(ctx,
 		http.MethodPost, fmt.Sprintf(api.UnityAPIStorageResourceActionURI, api.CreateFSAction), fileReqParam, fileResp)
+	if isResponseLost(err) {
+		existing, findErr := f.FindFilesystemByName(ctx, name)
+		if findErr == nil && existing.FileContent.NASServer.ID == nasServer {
+			log.Warnf("Create filesystem %s response lost, filesystem %s found created. Error: %v", name, existing.FileContent.ID, err)
+			return existing, nil
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -209,9 +473,64 @@ func (f *Filesystem) CreateFilesystem(ctx context.Context, name, storagepool, de
 	return fileResp, nil
 }
 
+//CreateFilesystemOnSP - Create a new filesystem served by the given storage processor (SPA or SPB). Unity places a
+//filesystem on the storage processor of it's NAS server and doesn't allow choosing it per filesystem, so the NAS server
+//must be the one currently running on the requested SP, ErrSPMismatch is returned otherwise. To balance workloads across
+//the SPs, create the filesystems on NAS servers homed on each SP.
+func (f *filesystem) CreateFilesystemOnSP(ctx context.Context, name, storagepool, description, nasServer string, size uint64, tieringPolicy, hostIOSize, supportedProtocol int, isThinEnabled, isDataReductionEnabled bool, spID string) (*types.Filesystem, error) {
+	if spID != SPA && spID != SPB {
+		return nil, fmt.Errorf("invalid storage processor Id: %s, should be %s or %s", spID, SPA, SPB)
+	}
+	nasServerResp, err := f.FindNASServerByID(ctx, nasServer)
+	if err != nil {
+		return nil, err
+	}
+	currentSP := nasServerResp.NASServerContent.CurrentSP.ID
+	if currentSP != spID {
+		return nil, fmt.Errorf("%w: NAS server %s is running on %s, requested %s", ErrSPMismatch, nasServer, currentSP, spID)
+	}
+	return f.CreateFilesystem(ctx, name, storagepool, description, nasServer, size, tieringPolicy, hostIOSize, supportedProtocol, isThinEnabled, isDataReductionEnabled)
+}
+
+//GetFilesystemCurrentSP - Get the Id of the storage processor (Ex: spa) currently serving the filesystem, which is the
+//current SP of it's NAS server. It differs from the NAS server home SP after a failover.
+func (f *filesystem) GetFilesystemCurrentSP(ctx context.Context, filesystemID string) (string, error) {
+	filesystemResp, err := f.FindFilesystemByID(ctx, filesystemID, "id", "nasServer")
+	if err != nil {
+		return "", err
+	}
+	nasServerResp, err := f.FindNASServerByID(ctx, filesystemResp.FileContent.NASServer.ID)
+	if err != nil {
+		return "", err
+	}
+	return nasServerResp.NASServerContent.CurrentSP.ID, nil
+}
+
+//GetFilesystemMountTarget - Returns the IP address of the first production file interface of the NAS server of the
+//filesystem, to mount it's NFS shares (Ex: with util.GetNFSExportPath). Backup file interfaces are ignored.
+func (f *filesystem) GetFilesystemMountTarget(ctx context.Context, filesystemID string) (string, error) {
+	filesystemResp, err := f.FindFilesystemByID(ctx, filesystemID, "id", "nasServer")
+	if err != nil {
+		return "", err
+	}
+	nasServerID := filesystemResp.FileContent.NASServer.ID
+	interfaces, err := NewIPInterface(f.client).listFileInterfaces(ctx, nasServerID)
+	if err != nil {
+		return "", err
+	}
+	for _, fileInterface := range interfaces {
+		content := fileInterface.FileInterfaceContent
+		if content.Role == FileInterfaceRoleProduction && content.IPAddress != "" {
+			return content.IPAddress, nil
+		}
+	}
+	return "", fmt.Errorf("NAS Server: %s of filesystem: %s has no production file interface", nasServerID, filesystemID)
+}
+
 //DeleteFilesystem delete by its ID. If the Filesystem is not present on the array, an error will be returned.
-func (f *Filesystem) DeleteFilesystem(ctx context.Context, filesystemID string) error {
-	log := util.GetRunIDLogger(ctx)
+//A filesystem having snapshots can't be deleted, it is marked for deletion and ErrFilesystemMarkedForDeletion is returned.
+func (f *filesystem) DeleteFilesystem(ctx context.Context, filesystemID string) error {
+	log := f.client.getLogger(ctx)
 	if len(filesystemID) == 0 {
 		return errors.New("Filesystem Id cannot be empty")
 	}
@@ -224,11 +543,13 @@ func (f *Filesystem) DeleteFilesystem(ctx context.Context, filesystemID string)
 	deleteErr := f.client.executeWithRetryAuthenticate(ctx, http.MethodDelete, fmt.Sprintf(api.UnityAPIGetResourceURI, api.StorageResourceAction, resourceID), nil, nil)
 	if deleteErr != nil {
 		if strings.Contains(deleteErr.Error(), AttachedSnapshotsErrorCode) {
-			err := f.updateDescription(ctx, filesystemID, MarkFilesystemForDeletion)
+			_, encodedTags := splitFilesystemDescription(filesystemResp.FileContent.Description)
+			err := f.updateDescription(ctx, filesystemID, joinFilesystemDescription(MarkFilesystemForDeletion, encodedTags))
 			if err != nil {
 				return fmt.Errorf("mark filesystem %s for deletion failed. Error: %v", filesystemID, err)
 			}
-			return nil
+			log.Debugf("Filesystem %s has snapshots, marked for deletion", filesystemID)
+			return fmt.Errorf("delete Filesystem %s deferred: %w", filesystemID, ErrFilesystemMarkedForDeletion)
 		}
 		return fmt.Errorf("delete Filesystem %s Failed. Error: %v", filesystemID, deleteErr)
 	}
@@ -236,8 +557,95 @@ func (f *Filesystem) DeleteFilesystem(ctx context.Context, filesystemID string)
 	return nil
 }
 
+//DeleteFilesystemAndWait - Delete the filesystem and poll every pollInterval until it is no longer found, so that
+//it's name can be reused right after. Waits at most DeleteFilesystemWaitTimeout or until the context is done.
+//ErrFilesystemMarkedForDeletion is returned right away for a filesystem having snapshots, it is not deleted yet.
+func (f *filesystem) DeleteFilesystemAndWait(ctx context.Context, filesystemID string, pollInterval time.Duration) error {
+	log := f.client.getLogger(ctx)
+	if pollInterval <= 0 {
+		return fmt.Errorf("invalid poll interval: %v", pollInterval)
+	}
+
+	err := f.DeleteFilesystem(ctx, filesystemID)
+	if err != nil {
+		return err
+	}
+
+	timeout := time.After(DeleteFilesystemWaitTimeout)
+	for {
+		_, err = f.FindFilesystemByID(ctx, filesystemID)
+		if err == ErrorFilesystemNotFound {
+			log.Debugf("Filesystem %s is deleted", filesystemID)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			return fmt.Errorf("filesystem %s still exists %v after delete", filesystemID, DeleteFilesystemWaitTimeout)
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+//ModifyFilesystemName - Rename the filesystem. The new name is validated with the rules of CreateFilesystem and
+//ErrorFilesystemNameConflict is returned if another filesystem has the name.
+func (f *filesystem) ModifyFilesystemName(ctx context.Context, filesystemID, newName string) error {
+	log := f.client.getLogger(ctx)
+	if len(filesystemID) == 0 {
+		return errors.New("Filesystem Id cannot be empty")
+	}
+	if err := ValidateFilesystemName(newName); err != nil {
+		return err
+	}
+	filesystemResp, err := f.FindFilesystemByID(ctx, filesystemID, "id", "name", "storageResource")
+	if err != nil {
+		return err
+	}
+	if filesystemResp.FileContent.Name == newName {
+		return nil
+	}
+	if err = f.checkFilesystemNameAvailable(ctx, filesystemID, newName); err != nil {
+		return err
+	}
+
+	resourceID := filesystemResp.FileContent.StorageResource.ID
+	filesystemModifyParam := types.FsModifyParameters{
+		Name: newName,
+	}
+	err = f.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityModifyFilesystemURI, resourceID), filesystemModifyParam, nil)
+	if err != nil {
+		//The name may be taken by a filesystem created concurrently
+		if conflictErr := f.checkFilesystemNameAvailable(ctx, filesystemID, newName); errors.Is(conflictErr, ErrorFilesystemNameConflict) {
+			return conflictErr
+		}
+		return fmt.Errorf("rename filesystem: %s to %s failed with error: %v", filesystemID, newName, err)
+	}
+	log.Debugf("Renamed filesystem %s from %s to %s", filesystemID, filesystemResp.FileContent.Name, newName)
+	return nil
+}
+
+//checkFilesystemNameAvailable returns ErrorFilesystemNameConflict if a filesystem other than the given one has the name
+func (f *filesystem) checkFilesystemNameAvailable(ctx context.Context, filesystemID, name string) error {
+	existing, err := f.FindFilesystemByName(ctx, name, "id", "name")
+	if err == ErrorFilesystemNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.FileContent.ID != filesystemID {
+		return fmt.Errorf("%w: %s (%s)", ErrorFilesystemNameConflict, name, existing.FileContent.ID)
+	}
+	return nil
+}
+
 //Update description of filesystem
-func (f *Filesystem) updateDescription(ctx context.Context, filesystemID, description string) error {
+func (f *filesystem) updateDescription(ctx context.Context, filesystemID, description string) error {
 	if len(filesystemID) == 0 {
 		return errors.New("Filesystem Id cannot be empty")
 	}
@@ -257,153 +665,1131 @@ func (f *Filesystem) updateDescription(ctx context.Context, filesystemID, descri
 	return nil
 }
 
-//CreateNFSShare - Create NFS Share for a File system
-func (f *Filesystem) CreateNFSShare(ctx context.Context, name, path, filesystemID string, nfsShareDefaultAccess NFSShareDefaultAccess) (*types.Filesystem, error) {
+//SetFilesystemTags - Replace the tags (Ex: owner, project) of the filesystem. Unity has no tags, so they are stored
+//JSON encoded in the description after FilesystemTagsMarker, the human readable part of the description is preserved.
+func (f *filesystem) SetFilesystemTags(ctx context.Context, filesystemID string, tags map[string]string) error {
 	if len(filesystemID) == 0 {
-		return nil, errors.New("Filesystem Id cannot be empty")
+		return errors.New("Filesystem Id cannot be empty")
 	}
-
 	filesystemResp, err := f.FindFilesystemByID(ctx, filesystemID)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	resourceID := filesystemResp.FileContent.StorageResource.ID
 
-	nfsShareParam := types.NFSShareParameters{
-		DefaultAccess: string(nfsShareDefaultAccess),
+	description, _ := splitFilesystemDescription(filesystemResp.FileContent.Description)
+	var encodedTags []byte
+	if len(tags) > 0 || description == "" {
+		//an empty description is not sent to the array, so the cleared tags are kept as an empty object then
+		encodedTags, err = json.Marshal(tags)
+		if err != nil {
+			return fmt.Errorf("unable to encode filesystem tags %v. Error: %v", tags, err)
+		}
 	}
+	return f.updateDescription(ctx, filesystemID, joinFilesystemDescription(description, string(encodedTags)))
+}
 
-	nfsShareCreateReqParam := types.NFSShareCreateParam{
-		Name:               name,
-		Path:               path,
-		NFSShareParameters: &nfsShareParam,
+//ModifyFilesystemDescription - Replace the human readable description of the filesystem, the tags set by
+//SetFilesystemTags are preserved. A filesystem marked for deletion by DeleteFilesystem keeps the mark in it's
+//description until it's last snapshot is deleted, hence ErrFilesystemMarkedForDeletion is returned for it.
+func (f *filesystem) ModifyFilesystemDescription(ctx context.Context, filesystemID, description string) error {
+	if len(filesystemID) == 0 {
+		return errors.New("Filesystem Id cannot be empty")
 	}
-
-	nfsShares := []types.NFSShareCreateParam{nfsShareCreateReqParam}
-	filesystemModifyParam := types.FsModifyParameters{
-		NFSShares: &nfsShares,
+	if strings.Contains(description, FilesystemTagsMarker) {
+		return fmt.Errorf("filesystem description should not contain the tags marker %s", FilesystemTagsMarker)
 	}
-
-	err = f.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityModifyFilesystemURI, resourceID), filesystemModifyParam, nil)
+	filesystemResp, err := f.FindFilesystemByID(ctx, filesystemID)
 	if err != nil {
-		return nil, fmt.Errorf("create NFS Share failed. Error: %v", err)
+		return err
 	}
 
-	filesystemResp, err = f.FindFilesystemByID(ctx, filesystemID)
-	if err != nil {
-		return nil, ErrorFilesystemNotFound
+	currentDescription, encodedTags := splitFilesystemDescription(filesystemResp.FileContent.Description)
+	if strings.Contains(currentDescription, MarkFilesystemForDeletion) {
+		return fmt.Errorf("unable to modify description of filesystem %s: %w", filesystemID, ErrFilesystemMarkedForDeletion)
 	}
-	return filesystemResp, nil
+	if description == "" && encodedTags == "" {
+		//an empty description is not sent to the array, the tags are kept as an empty object to clear it
+		encodedTags = "{}"
+	}
+	return f.updateDescription(ctx, filesystemID, joinFilesystemDescription(description, encodedTags))
 }
 
-//CreateNFSShareFromSnapshot - Create NFS Share for a File system Snapshot
-func (f *Filesystem) CreateNFSShareFromSnapshot(ctx context.Context, name, path, snapshotID string, nfsShareDefaultAccess NFSShareDefaultAccess) (*types.NFSShare, error) {
-	if len(snapshotID) == 0 {
-		return nil, errors.New("Snapshot Id cannot be empty")
+//GetFilesystemDescription - Get the human readable description of the filesystem, without the tags
+func (f *filesystem) GetFilesystemDescription(ctx context.Context, filesystemID string) (string, error) {
+	if len(filesystemID) == 0 {
+		return "", errors.New("Filesystem Id cannot be empty")
+	}
+	filesystemResp, err := f.FindFilesystemByID(ctx, filesystemID)
+	if err != nil {
+		return "", err
 	}
+	description, _ := splitFilesystemDescription(filesystemResp.FileContent.Description)
+	return description, nil
+}
 
-	snapshotContent := types.SnapshotIDContent{
-		ID: snapshotID,
+//GetFilesystemTags - Get the tags of the filesystem set by SetFilesystemTags. An empty map is returned for a filesystem without tags.
+func (f *filesystem) GetFilesystemTags(ctx context.Context, filesystemID string) (map[string]string, error) {
+	if len(filesystemID) == 0 {
+		return nil, errors.New("Filesystem Id cannot be empty")
+	}
+	filesystemResp, err := f.FindFilesystemByID(ctx, filesystemID)
+	if err != nil {
+		return nil, err
 	}
 
-	nfsShareCreateReq := types.NFSShareCreateFromSnapParam{
-		Name:          name,
-		Path:          path,
-		DefaultAccess: string(nfsShareDefaultAccess),
-		Snapshot:      snapshotContent,
+	tags := make(map[string]string)
+	_, encodedTags := splitFilesystemDescription(filesystemResp.FileContent.Description)
+	if encodedTags == "" {
+		return tags, nil
 	}
+	if err = json.Unmarshal([]byte(encodedTags), &tags); err != nil {
+		return nil, fmt.Errorf("unable to decode tags of filesystem: %s. Error: %v", filesystemID, err)
+	}
+	return tags, nil
+}
 
-	nfsShareResp := &types.NFSShare{}
-	err := f.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityAPIInstanceTypeResources, api.NfsShareAction), nfsShareCreateReq, nfsShareResp)
-	if err != nil {
-		return nil, fmt.Errorf("create NFS Share: %s failed. Error: %v", name, err)
+//splitFilesystemDescription splits the description into the human readable description and the JSON encoded tags
+func splitFilesystemDescription(description string) (string, string) {
+	index := strings.LastIndex(description, FilesystemTagsMarker)
+	if index < 0 {
+		return description, ""
 	}
+	return strings.TrimSpace(description[:index]), description[index+len(FilesystemTagsMarker):]
+}
 
-	return nfsShareResp, nil
+//joinFilesystemDescription is the reverse of splitFilesystemDescription, the tags are omitted when empty
+func joinFilesystemDescription(description, encodedTags string) string {
+	if encodedTags == "" {
+		return description
+	}
+	return strings.TrimSpace(description + " " + FilesystemTagsMarker + encodedTags)
 }
 
-//FindNFSShareByName - Find the NFS Share by it's name. If the NFS Share is not found, an error will be returned.
-func (f *Filesystem) FindNFSShareByName(ctx context.Context, nfsSharename string) (*types.NFSShare, error) {
-	if len(nfsSharename) == 0 {
-		return nil, errors.New("NFS Share Name shouldn't be empty")
+//ModifyFilesystemEventSettings - Modify the file event (CEPA) publishing settings of the filesystem
+func (f *filesystem) ModifyFilesystemEventSettings(ctx context.Context, filesystemID string, fileEventSettings types.FileEventSettings) error {
+	log := f.client.getLogger(ctx)
+	if len(filesystemID) == 0 {
+		return errors.New("Filesystem Id cannot be empty")
 	}
-	nfsShareResp := &types.NFSShare{}
-	err := f.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceByNameWithFieldsURI, api.NfsShareAction, nfsSharename, NFSShareDisplayfields), nil, nfsShareResp)
+
+	filesystemResp, err := f.FindFilesystemByID(ctx, filesystemID)
 	if err != nil {
-		return nil, fmt.Errorf("unable to find NFS Share. Error: %v", err)
+		return err
 	}
-	return nfsShareResp, nil
-}
+	resourceID := filesystemResp.FileContent.StorageResource.ID
 
-//FindNFSShareByID - Find the NFS Share by it's Id. If the NFS Share is not found, an error will be returned.
-func (f *Filesystem) FindNFSShareByID(ctx context.Context, nfsShareID string) (*types.NFSShare, error) {
-	if len(nfsShareID) == 0 {
-		return nil, errors.New("NFS Share Id shouldn't be empty")
+	filesystemModifyParam := types.FsModifyParameters{
+		FsParameters: &types.FsModifyFsParameters{
+			FileEventSettings: &fileEventSettings,
+		},
 	}
-	nfsShareResp := &types.NFSShare{}
-	err := f.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceWithFieldsURI, api.NfsShareAction, nfsShareID, NFSShareDisplayfields), nil, nfsShareResp)
+	err = f.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityModifyFilesystemURI, resourceID), filesystemModifyParam, nil)
 	if err != nil {
-		return nil, fmt.Errorf("unable to find NFS Share: %s. Error: %v", nfsShareID, err)
+		return fmt.Errorf("modify filesystem: %s event settings failed with error: %v", filesystemID, err)
 	}
-	return nfsShareResp, nil
+	log.Debugf("Modify filesystem: %s event settings to %+v successful", filesystemID, fileEventSettings)
+	return nil
 }
 
-//ModifyNFSShareHostAccess - Modify the host access on NFS Share
-func (f *Filesystem) ModifyNFSShareHostAccess(ctx context.Context, filesystemID, nfsShareID string, hostIDs []string, accessType AccessType) error {
-	log := util.GetRunIDLogger(ctx)
+//EnableCIFSOnFilesystem - Enable CIFS (SMB) on an existing filesystem by enabling CIFS in it's file event settings.
+//The NFS setting is preserved and the NAS server of the filesystem must have a CIFS server.
+func (f *filesystem) EnableCIFSOnFilesystem(ctx context.Context, filesystemID string) error {
 	if len(filesystemID) == 0 {
 		return errors.New("Filesystem Id cannot be empty")
 	}
 
 	filesystemResp, err := f.FindFilesystemByID(ctx, filesystemID)
 	if err != nil {
-		return ErrorFilesystemNotFound
+		return err
 	}
-	resourceID := filesystemResp.FileContent.StorageResource.ID
 
-	hostsIdsContent := []types.HostIDContent{}
-	for _, hostID := range hostIDs {
-		hostIDContent := types.HostIDContent{
-			ID: hostID,
-		}
-		hostsIdsContent = append(hostsIdsContent, hostIDContent)
+	nasServerID := filesystemResp.FileContent.NASServer.ID
+	nasServer, err := f.FindNASServerByID(ctx, nasServerID)
+	if err != nil {
+		return err
+	}
+	if len(nasServer.NASServerContent.CIFSServers) == 0 {
+		return fmt.Errorf("enable CIFS on filesystem: %s failed. NAS server %s has no CIFS server", filesystemID, nasServerID)
+	}
+
+	fileEventSettings := filesystemResp.FileContent.FileEventSettings
+	if fileEventSettings.IsCIFSEnabled {
+		return nil
+	}
+	fileEventSettings.IsCIFSEnabled = true
+	return f.ModifyFilesystemEventSettings(ctx, filesystemID, fileEventSettings)
+}
+
+//SetFilesystemSnapAutoDeletePolicy - Set the snapshot auto delete policies of the filesystem, applied when the pool reaches
+//it's full high watermark (poolFullPolicy) or the snapshots reach their space used high watermark (spaceUsedPolicy).
+//This caps the pool space consumed by the snapshots of the filesystem.
+func (f *filesystem) SetFilesystemSnapAutoDeletePolicy(ctx context.Context, filesystemID string, poolFullPolicy, spaceUsedPolicy int) error {
+	log := f.client.getLogger(ctx)
+	if len(filesystemID) == 0 {
+		return errors.New("Filesystem Id cannot be empty")
+	}
+
+	for _, policy := range []int{poolFullPolicy, spaceUsedPolicy} {
+		if policy != SnapAutoDeletePolicyDisabled && policy != SnapAutoDeletePolicyEnabled {
+			return fmt.Errorf("invalid snapshot auto delete policy: %d", policy)
+		}
+	}
+
+	filesystemResp, err := f.FindFilesystemByID(ctx, filesystemID)
+	if err != nil {
+		return err
+	}
+	resourceID := filesystemResp.FileContent.StorageResource.ID
+
+	filesystemModifyParam := types.FsModifyParameters{
+		SnapScheduleParameters: &types.SnapScheduleParameters{
+			PoolFullPolicy:  poolFullPolicy,
+			SpaceUsedPolicy: spaceUsedPolicy,
+		},
+	}
+	err = f.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityModifyFilesystemURI, resourceID), filesystemModifyParam, nil)
+	if err != nil {
+		return fmt.Errorf("set filesystem: %s snapshot auto delete policy failed with error: %v", filesystemID, err)
+	}
+	log.Debugf("Set filesystem: %s snapshot auto delete policy (pool full: %d, space used: %d) successful", filesystemID, poolFullPolicy, spaceUsedPolicy)
+	return nil
+}
+
+//CreateNFSShare - Create NFS Share for a File system. If the share already exists on the filesystem with the same path
+//and default access, the filesystem is returned as for a new share, ErrorNFSShareConflict is returned if they differ.
+//The path is normalized with util.NormalizeNFSSharePath (Ex: "export/" is created as "/export").
+func (f *filesystem) CreateNFSShare(ctx context.Context, name, path, filesystemID string, nfsShareDefaultAccess NFSShareDefaultAccess) (*types.Filesystem, error) {
+	nfsShareParam := types.NFSShareParameters{
+		DefaultAccess: string(nfsShareDefaultAccess),
+	}
+	return f.createNFSShare(ctx, name, path, filesystemID, nfsShareParam)
+}
+
+//CreateNFSShareWithKerberos - Create NFS Share for a File system requiring the given minimum Kerberos security from the
+//NFS clients, owned by nfsOwnerUsername when not empty. ErrKerberosNotConfigured is returned before the create if the
+//NAS server has no secure NFS (Kerberos) configured, or no SMB server joined to the AD domain for a Windows KDC.
+func (f *filesystem) CreateNFSShareWithKerberos(ctx context.Context, name, path, filesystemID string, nfsShareDefaultAccess NFSShareDefaultAccess, minSecurity NFSShareSecurity, nfsOwnerUsername string) (*types.Filesystem, error) {
+	if minSecurity < KerberosSecurity || minSecurity > KerberosWithEncryptionSecurity {
+		return nil, fmt.Errorf("invalid Kerberos security: %d, it should be in between %d-%d", minSecurity, KerberosSecurity, KerberosWithEncryptionSecurity)
+	}
+	if len(filesystemID) == 0 {
+		return nil, errors.New("Filesystem Id cannot be empty")
+	}
+	filesystemResp, err := f.FindFilesystemByID(ctx, filesystemID, "id", "nasServer")
+	if err != nil {
+		return nil, err
+	}
+	if err = f.checkKerberosConfigured(ctx, filesystemResp.FileContent.NASServer.ID); err != nil {
+		return nil, err
+	}
+
+	nfsShareParam := types.NFSShareParameters{
+		DefaultAccess:    string(nfsShareDefaultAccess),
+		MinSecurity:      int(minSecurity),
+		NFSOwnerUsername: nfsOwnerUsername,
+	}
+	return f.createNFSShare(ctx, name, path, filesystemID, nfsShareParam)
+}
+
+//createNFSShare creates the NFS share with the given parameters on the filesystem, see CreateNFSShare
+func (f *filesystem) createNFSShare(ctx context.Context, name, path, filesystemID string, nfsShareParam types.NFSShareParameters) (*types.Filesystem, error) {
+	log := f.client.getLogger(ctx)
+	if len(filesystemID) == 0 {
+		return nil, errors.New("Filesystem Id cannot be empty")
+	}
+
+	path = util.NormalizeNFSSharePath(path)
+	nfsShareCreateReqParam := types.NFSShareCreateParam{
+		Name:               name,
+		Path:               path,
+		NFSShareParameters: &nfsShareParam,
+	}
+	if err := validateNFSShareCreateParam(&nfsShareCreateReqParam); err != nil {
+		return nil, err
+	}
+
+	filesystemResp, err := f.FindFilesystemByID(ctx, filesystemID)
+	if err != nil {
+		return nil, err
+	}
+	resourceID := filesystemResp.FileContent.StorageResource.ID
+
+	existingNFSShare, err := f.FindNFSShareByNameAndFilesystem(ctx, name, filesystemID)
+	if err == nil {
+		existing := existingNFSShare.NFSShareContent
+		if !nfsShareMatches(existing, path, nfsShareParam) {
+			return nil, fmt.Errorf("create NFS Share: %s failed, path: %s default access: %d min security: %d NFS owner: %s exist: %w",
+				name, existing.Path, existing.DefaultAccess, existing.MinSecurity, existing.NFSOwnerUsername, ErrorNFSShareConflict)
+		}
+		log.Infof("NFS Share: %s already exists on filesystem: %s", name, filesystemID)
+		return filesystemResp, nil
+	} else if err != ErrorNFSShareNotFound {
+		return nil, err
+	}
+
+	if err = f.checkNFSServerConfigured(ctx, filesystemResp.FileContent.NASServer.ID); err != nil {
+		return nil, err
+	}
+
+	nfsShares := []types.NFSShareCreateParam{nfsShareCreateReqParam}
+	filesystemModifyParam := types.FsModifyParameters{
+		NFSShares: &nfsShares,
+	}
+
+	err = f.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityModifyFilesystemURI, resourceID), filesystemModifyParam, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create NFS Share failed. Error: %v", err)
+	}
+
+	if !f.skipVerificationRead {
+		if _, err = f.FindNFSShareByNameAndFilesystem(ctx, name, filesystemID); err != nil {
+			return nil, fmt.Errorf("NFS Share: %s not found on filesystem: %s after create. Error: %v", name, filesystemID, err)
+		}
+	}
+
+	filesystemResp, err = f.FindFilesystemByID(ctx, filesystemID)
+	if err != nil {
+		return nil, ErrorFilesystemNotFound
+	}
+	return filesystemResp, nil
+}
+
+//nfsShareMatches returns true if the existing NFS share has the path and default access of the NFS share to be created,
+//and the minimum security, NFS owner and host access lists when they are requested (Ex: a Kerberos share or a copy)
+func nfsShareMatches(existing types.NFSShareContent, path string, nfsShareParam types.NFSShareParameters) bool {
+	if util.NormalizeNFSSharePath(existing.Path) != path || strconv.Itoa(existing.DefaultAccess) != nfsShareParam.DefaultAccess {
+		return false
+	}
+	if nfsShareParam.MinSecurity != 0 && existing.MinSecurity != nfsShareParam.MinSecurity {
+		return false
+	}
+	if nfsShareParam.NFSOwnerUsername != "" && existing.NFSOwnerUsername != nfsShareParam.NFSOwnerUsername {
+		return false
+	}
+	return sameHosts(existing.ReadOnlyHosts, nfsShareParam.ReadOnlyHosts) &&
+		sameHosts(existing.ReadWriteHosts, nfsShareParam.ReadWriteHosts) &&
+		sameHosts(existing.ReadOnlyRootAccessHosts, nfsShareParam.ReadOnlyRootAccessHosts) &&
+		sameHosts(existing.RootAccessHosts, nfsShareParam.RootAccessHosts) &&
+		sameHosts(existing.NoAccessHosts, nfsShareParam.NoAccessHosts)
+}
+
+//sameHosts returns true if the existing host access list has exactly the requested hosts, or no hosts are requested
+func sameHosts(existing []types.HostContent, requested *[]types.HostIDContent) bool {
+	if requested == nil {
+		return true
+	}
+	if len(existing) != len(*requested) {
+		return false
+	}
+	existingIDs := make(map[string]bool)
+	for _, host := range existing {
+		existingIDs[host.ID] = true
+	}
+	for _, host := range *requested {
+		if !existingIDs[host.ID] {
+			return false
+		}
+	}
+	return true
+}
+
+//CopyNFSShareConfig - Create a NFS share on the destination filesystem with the name, path, default access and host
+//access lists of the source NFS share (Ex: for a DR or test copy of a filesystem). The path must exist on the destination
+//filesystem, and since share names are unique per NAS server the destination filesystem should be on another NAS server.
+//Hosts of the source access lists which no longer exist on the array are skipped. The host access lists are set by the
+//create request itself, so a failed copy leaves no half configured share; an existing share of the destination with the
+//same name, path, default access and host access lists is returned as it is, ErrorNFSShareConflict is returned when
+//they differ.
+func (f *filesystem) CopyNFSShareConfig(ctx context.Context, srcNFSShareID, dstFilesystemID string) (*types.NFSShare, error) {
+	log := f.client.getLogger(ctx)
+	if len(dstFilesystemID) == 0 {
+		return nil, errors.New("Filesystem Id cannot be empty")
+	}
+	srcNFSShare, err := f.FindNFSShareByID(ctx, srcNFSShareID)
+	if err != nil {
+		return nil, err
+	}
+	src := srcNFSShare.NFSShareContent
+
+	hostsResp := &types.ListHosts{}
+	err = f.client.QueryInstances(ctx, api.HostAction, []string{"id"}, "", hostsResp)
+	if err != nil {
+		return nil, err
+	}
+	existingHosts := make(map[string]bool)
+	for _, host := range hostsResp.Hosts {
+		existingHosts[host.HostContent.ID] = true
+	}
+	existingHostIDContents := func(hosts []types.HostContent) *[]types.HostIDContent {
+		hostsIdsContent := []types.HostIDContent{}
+		for _, host := range hosts {
+			if !existingHosts[host.ID] {
+				log.Warnf("Host %s of NFS Share %s no longer exists, not copied", host.ID, srcNFSShareID)
+				continue
+			}
+			hostsIdsContent = append(hostsIdsContent, types.HostIDContent{ID: host.ID})
+		}
+		return &hostsIdsContent
+	}
+
+	nfsShareParam := types.NFSShareParameters{
+		DefaultAccess:           strconv.Itoa(src.DefaultAccess),
+		ReadOnlyHosts:           existingHostIDContents(src.ReadOnlyHosts),
+		ReadWriteHosts:          existingHostIDContents(src.ReadWriteHosts),
+		ReadOnlyRootAccessHosts: existingHostIDContents(src.ReadOnlyRootAccessHosts),
+		RootAccessHosts:         existingHostIDContents(src.RootAccessHosts),
+		NoAccessHosts:           existingHostIDContents(src.NoAccessHosts),
+	}
+	_, err = f.createNFSShare(ctx, src.Name, src.Path, dstFilesystemID, nfsShareParam)
+	if err != nil {
+		return nil, fmt.Errorf("copy NFS Share %s to filesystem %s failed. Error: %w", srcNFSShareID, dstFilesystemID, err)
+	}
+	return f.FindNFSShareByNameAndFilesystem(ctx, src.Name, dstFilesystemID)
+}
+
+//RehomeNFSShare - Move a NFS share to the target filesystem: the share is recreated on the target filesystem with
+//CopyNFSShareConfig and the original share is deleted. The recreated share is deleted again if the copy or the deletion
+//of the original fails, leaving the original share as it was. The export path changes with the filesystem (and NAS
+//server), hence the clients must remount the share from the export path of the returned share.
+//Since NFS share names are unique per NAS server and the original share is deleted only once the copy exists, the target
+//filesystem should be on another NAS server than the share, ErrRehomeToSameNASServer is returned otherwise.
+func (f *filesystem) RehomeNFSShare(ctx context.Context, nfsShareID, targetFilesystemID string) (*types.NFSShare, error) {
+	log := f.client.getLogger(ctx)
+	if len(targetFilesystemID) == 0 {
+		return nil, errors.New("Filesystem Id cannot be empty")
+	}
+	srcNFSShare, err := f.FindNFSShareByID(ctx, nfsShareID)
+	if err != nil {
+		return nil, err
+	}
+	src := srcNFSShare.NFSShareContent
+	if len(src.Filesystem.ID) == 0 {
+		return nil, fmt.Errorf("NFS Share %s is not a filesystem NFS Share and cannot be rehomed", nfsShareID)
+	}
+	if src.Filesystem.ID == targetFilesystemID {
+		return nil, fmt.Errorf("NFS Share %s is already on filesystem %s", nfsShareID, targetFilesystemID)
+	}
+	srcFilesystem, err := f.FindFilesystemByID(ctx, src.Filesystem.ID, "id", "nasServer")
+	if err != nil {
+		return nil, err
+	}
+	targetFilesystem, err := f.FindFilesystemByID(ctx, targetFilesystemID, "id", "nasServer")
+	if err != nil {
+		return nil, err
+	}
+	if srcFilesystem.FileContent.NASServer.ID == targetFilesystem.FileContent.NASServer.ID {
+		return nil, fmt.Errorf("rehome NFS Share %s to filesystem %s of NAS Server %s failed: %w", nfsShareID, targetFilesystemID, targetFilesystem.FileContent.NASServer.ID, ErrRehomeToSameNASServer)
+	}
+	//A share of the same name on the target would be reused by the copy and deleted by the rollback
+	_, err = f.FindNFSShareByNameAndFilesystem(ctx, src.Name, targetFilesystemID)
+	if err == nil {
+		return nil, fmt.Errorf("NFS Share %s already exists on filesystem %s. Error: %w", src.Name, targetFilesystemID, ErrorNFSShareConflict)
+	}
+	if err != ErrorNFSShareNotFound {
+		return nil, err
+	}
+
+	dstNFSShare, err := f.CopyNFSShareConfig(ctx, nfsShareID, targetFilesystemID)
+	if err != nil {
+		f.rollbackRehomedNFSShare(ctx, src.Name, targetFilesystemID)
+		return nil, fmt.Errorf("rehome NFS Share %s to filesystem %s failed. Error: %v", nfsShareID, targetFilesystemID, err)
+	}
+	err = f.DeleteNFSShare(ctx, src.Filesystem.ID, nfsShareID)
+	if err != nil {
+		f.rollbackRehomedNFSShare(ctx, src.Name, targetFilesystemID)
+		return nil, fmt.Errorf("delete of NFS Share %s rehomed to filesystem %s failed. Error: %v", nfsShareID, targetFilesystemID, err)
+	}
+	log.Infof("NFS Share %s rehomed to filesystem %s as NFS Share %s", nfsShareID, targetFilesystemID, dstNFSShare.NFSShareContent.ID)
+	return dstNFSShare, nil
+}
+
+//rollbackRehomedNFSShare deletes the NFS share recreated on the target filesystem by RehomeNFSShare, if it was created
+func (f *filesystem) rollbackRehomedNFSShare(ctx context.Context, name, targetFilesystemID string) {
+	log := f.client.getLogger(ctx)
+	dstNFSShare, err := f.FindNFSShareByNameAndFilesystem(ctx, name, targetFilesystemID)
+	if err != nil {
+		if err != ErrorNFSShareNotFound {
+			log.Warnf("Unable to find NFS Share %s on filesystem %s for rollback. Error: %v", name, targetFilesystemID, err)
+		}
+		return
+	}
+	err = f.DeleteNFSShare(ctx, targetFilesystemID, dstNFSShare.NFSShareContent.ID)
+	if err != nil {
+		log.Warnf("Rollback of NFS Share %s on filesystem %s failed. Error: %v", name, targetFilesystemID, err)
+	}
+}
+
+//CreateNFSShareFromSnapshot - Create NFS Share for a File system Snapshot
+func (f *filesystem) CreateNFSShareFromSnapshot(ctx context.Context, name, path, snapshotID string, nfsShareDefaultAccess NFSShareDefaultAccess) (*types.NFSShare, error) {
+	if len(snapshotID) == 0 {
+		return nil, errors.New("Snapshot Id cannot be empty")
+	}
+
+	snapshotContent := types.SnapshotIDContent{
+		ID: snapshotID,
+	}
+
+	nfsShareCreateReq := types.NFSShareCreateFromSnapParam{
+		Name:          name,
+		Path:          path,
+		DefaultAccess: string(nfsShareDefaultAccess),
+		Snapshot:      snapshotContent,
+	}
+	return f.createNFSShareFromSnapshot(ctx, nfsShareCreateReq)
+}
+
+//CreateNFSShareFromSnapshotWhenReady - Create NFS Share for a File system Snapshot once the snapshot is ready, since
+//the share creation fails on a snapshot still being created. See Snapshot.WaitForSnapshotReady for the wait errors.
+func (f *filesystem) CreateNFSShareFromSnapshotWhenReady(ctx context.Context, name, path, snapshotID string, nfsShareDefaultAccess NFSShareDefaultAccess, pollInterval time.Duration) (*types.NFSShare, error) {
+	if len(snapshotID) == 0 {
+		return nil, errors.New("Snapshot Id cannot be empty")
+	}
+	if err := NewSnapshot(f.client).WaitForSnapshotReady(ctx, snapshotID, pollInterval); err != nil {
+		return nil, err
+	}
+	return f.CreateNFSShareFromSnapshot(ctx, name, path, snapshotID, nfsShareDefaultAccess)
+}
+
+//CreateNFSShareFromLatestSnapshot - Create a read-only NFS Share from the most recent snapshot of the filesystem (Ex: the
+//last one taken by a snapshot schedule), to verify a backup without knowing the generated snapshot name.
+func (f *filesystem) CreateNFSShareFromLatestSnapshot(ctx context.Context, name, path, filesystemID string, nfsShareDefaultAccess NFSShareDefaultAccess) (*types.NFSShare, error) {
+	filesystem, err := f.FindFilesystemByID(ctx, filesystemID, "id", "storageResource")
+	if err != nil {
+		return nil, err
+	}
+	snapshotsResp := &types.ListSnapshot{}
+	filter := fmt.Sprintf("storageResource.id eq \"%s\"", filesystem.FileContent.StorageResource.ID)
+	err = f.client.QueryInstances(ctx, api.SnapAction, []string{"id", "name", "creationTime"}, filter, snapshotsResp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list snapshots of filesystem: %s. Error: %v", filesystemID, err)
+	}
+	var latest *types.SnapshotContent
+	for i, snapshot := range snapshotsResp.Snapshots {
+		if latest == nil || snapshot.SnapshotContent.CreationTime.After(latest.CreationTime) {
+			latest = &snapshotsResp.Snapshots[i].SnapshotContent
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("filesystem: %s has no snapshot to create NFS Share: %s from", filesystemID, name)
+	}
+
+	isReadOnly := true
+	nfsShareCreateReq := types.NFSShareCreateFromSnapParam{
+		Name:          name,
+		Path:          path,
+		DefaultAccess: string(nfsShareDefaultAccess),
+		Snapshot:      types.SnapshotIDContent{ID: latest.ResourceID},
+		IsReadOnly:    &isReadOnly,
+	}
+	return f.createNFSShareFromSnapshot(ctx, nfsShareCreateReq)
+}
+
+//createNFSShareFromSnapshot sends the NFS share create request for a snapshot
+func (f *filesystem) createNFSShareFromSnapshot(ctx context.Context, nfsShareCreateReq types.NFSShareCreateFromSnapParam) (*types.NFSShare, error) {
+	nfsShareCreateReq.Path = util.NormalizeNFSSharePath(nfsShareCreateReq.Path)
+	nfsShareResp := &types.NFSShare{}
+	err := f.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityAPIInstanceTypeResources, api.NfsShareAction), nfsShareCreateReq, nfsShareResp)
+	if err != nil {
+		return nil, fmt.Errorf("create NFS Share: %s failed. Error: %v", nfsShareCreateReq.Name, err)
+	}
+
+	return nfsShareResp, nil
+}
+
+//FindNFSShareByName - Find the NFS Share by it's name. If the NFS Share is not found, an error will be returned.
+//Only the given fields are fetched when any, NFSShareDisplayfields otherwise.
+func (f *filesystem) FindNFSShareByName(ctx context.Context, nfsSharename string, fields ...string) (*types.NFSShare, error) {
+	if len(nfsSharename) == 0 {
+		return nil, errors.New("NFS Share Name shouldn't be empty")
+	}
+	nfsShareResp := &types.NFSShare{}
+	err := f.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceByNameWithFieldsURI, api.NfsShareAction, nfsSharename, displayFields(NFSShareDisplayfields, fields)), nil, nfsShareResp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find NFS Share. Error: %v", err)
+	}
+	return nfsShareResp, nil
+}
+
+//FindNFSShareByID - Find the NFS Share by it's Id. If the NFS Share is not found, an error will be returned.
+//Only the given fields are fetched when any, NFSShareDisplayfields otherwise.
+func (f *filesystem) FindNFSShareByID(ctx context.Context, nfsShareID string, fields ...string) (*types.NFSShare, error) {
+	if len(nfsShareID) == 0 {
+		return nil, errors.New("NFS Share Id shouldn't be empty")
+	}
+	nfsShareResp := &types.NFSShare{}
+	err := f.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceWithFieldsURI, api.NfsShareAction, nfsShareID, displayFields(NFSShareDisplayfields, fields)), nil, nfsShareResp)
+	if err != nil {
+		if strings.Contains(err.Error(), NFSShareNotFoundErrorCode) {
+			return nil, ErrorNFSShareNotFound
+		}
+		return nil, fmt.Errorf("unable to find NFS Share: %s. Error: %v", nfsShareID, err)
+	}
+	return nfsShareResp, nil
+}
+
+//NFSShareExists - Check whether a NFS Share exists with the given Id or name, without fetching the NFS Share
+func (f *filesystem) NFSShareExists(ctx context.Context, nameOrID string) (bool, error) {
+	return f.client.resourceExists(ctx, api.NfsShareAction, nameOrID)
+}
+
+//FindNFSShareByNameAndFilesystem - Find the NFS share of the filesystem by it's name. If the NFS share is not found, ErrorNFSShareNotFound is returned.
+func (f *filesystem) FindNFSShareByNameAndFilesystem(ctx context.Context, nfsShareName, filesystemID string) (*types.NFSShare, error) {
+	if len(nfsShareName) == 0 {
+		return nil, errors.New("NFS Share Name shouldn't be empty")
+	}
+	if len(filesystemID) == 0 {
+		return nil, errors.New("Filesystem Id cannot be empty")
+	}
+
+	nfsSharesResp := &types.ListNFSShares{}
+	filter := fmt.Sprintf("name eq \"%s\" and filesystem.id eq \"%s\"", nfsShareName, filesystemID)
+	err := f.client.QueryInstances(ctx, api.NfsShareAction, strings.Split(NFSShareDisplayfields, ","), filter, nfsSharesResp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find NFS Share. Error: %v", err)
+	}
+	if len(nfsSharesResp.NFSShares) == 0 {
+		return nil, ErrorNFSShareNotFound
+	}
+	return &nfsSharesResp.NFSShares[0], nil
+}
+
+//FindNFSShareByPath - Find the NFS Share of the filesystem by it's path, compared once normalized with
+//util.NormalizeNFSSharePath. ErrorNFSShareNotFound is returned if no share has the path and
+//ErrorMultipleNFSSharesWithPath if several shares have it.
+func (f *filesystem) FindNFSShareByPath(ctx context.Context, filesystemID, path string) (*types.NFSShare, error) {
+	if len(filesystemID) == 0 {
+		return nil, errors.New("Filesystem Id cannot be empty")
+	}
+	if len(path) == 0 {
+		return nil, errors.New("NFS Share Path shouldn't be empty")
+	}
+	path = util.NormalizeNFSSharePath(path)
+
+	nfsSharesResp := &types.ListNFSShares{}
+	filter := fmt.Sprintf("filesystem.id eq \"%s\"", filesystemID)
+	err := f.client.QueryInstances(ctx, api.NfsShareAction, strings.Split(NFSShareDisplayfields, ","), filter, nfsSharesResp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find NFS Share. Error: %v", err)
+	}
+	var found *types.NFSShare
+	for i, nfsShare := range nfsSharesResp.NFSShares {
+		if util.NormalizeNFSSharePath(nfsShare.NFSShareContent.Path) != path {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("%w: %s on filesystem: %s (%s, %s)", ErrorMultipleNFSSharesWithPath, path, filesystemID, found.NFSShareContent.ID, nfsShare.NFSShareContent.ID)
+		}
+		found = &nfsSharesResp.NFSShares[i]
+	}
+	if found == nil {
+		return nil, ErrorNFSShareNotFound
+	}
+	return found, nil
+}
+
+//ListFilesystems - List a page of maxEntries filesystems (DefaultListPageSize if 0). The returned token, empty on the
+//last page, is passed back as nextToken to list the next page.
+func (f *filesystem) ListFilesystems(ctx context.Context, nextToken string, maxEntries int) ([]types.Filesystem, string, error) {
+	filesystemsResp := &types.ListFilesystems{}
+	token, err := f.client.listPage(ctx, api.FileSystemAction, strings.Split(FileSystemDisplayFields, ","), "", nextToken, maxEntries, filesystemsResp)
+	if err != nil {
+		return nil, "", err
+	}
+	return filesystemsResp.Filesystems, token, nil
+}
+
+//ListNFSShares - List a page of maxEntries NFS shares (DefaultListPageSize if 0). The returned token, empty on the last
+//page, is passed back as nextToken to list the next page.
+func (f *filesystem) ListNFSShares(ctx context.Context, nextToken string, maxEntries int) ([]types.NFSShare, string, error) {
+	nfsSharesResp := &types.ListNFSShares{}
+	token, err := f.client.listPage(ctx, api.NfsShareAction, strings.Split(NFSShareDisplayfields, ","), "", nextToken, maxEntries, nfsSharesResp)
+	if err != nil {
+		return nil, "", err
+	}
+	return nfsSharesResp.NFSShares, token, nil
+}
+
+//ListNFSSharesForHost - List the NFS shares the host has access to through any of the four host access lists, out of
+//a page of maxEntries NFS shares (DefaultListPageSize if 0). The returned token, empty on the last page, is passed back
+//as nextToken to list the next page. Unity cannot filter on the host access lists, so each page of NFS shares is
+//filtered here: a page may hold fewer NFS shares than maxEntries, even none, while the returned token is not empty.
+func (f *filesystem) ListNFSSharesForHost(ctx context.Context, hostID, nextToken string, maxEntries int) ([]types.NFSShare, string, error) {
+	if len(hostID) == 0 {
+		return nil, "", errors.New("Host Id cannot be empty")
+	}
+
+	nfsSharesResp := &types.ListNFSShares{}
+	token, err := f.client.listPage(ctx, api.NfsShareAction, strings.Split(NFSShareDisplayfields, ","), "", nextToken, maxEntries, nfsSharesResp)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nfsShares := []types.NFSShare{}
+	for _, nfsShare := range nfsSharesResp.NFSShares {
+		for _, accessType := range []AccessType{ReadOnlyAccessType, ReadWriteAccessType, ReadOnlyRootAccessType, ReadWriteRootAccessType} {
+			if containsHostID(getNFSShareHostIDs(&nfsShare, accessType), hostID) {
+				nfsShares = append(nfsShares, nfsShare)
+				break
+			}
+		}
+	}
+	return nfsShares, token, nil
+}
+
+//GetEffectiveHostAccess - Get the access of the host to the NFS share. The no access list prevails, then the host access
+//list the host is present in, the default access of the share otherwise (NoAccessType for NoneDefaultAccess). The write
+//access is dropped on a read-only share (Ex: a share of a read-only snapshot) whatever the list.
+func (f *filesystem) GetEffectiveHostAccess(ctx context.Context, nfsShareID, hostID string) (AccessType, error) {
+	if len(hostID) == 0 {
+		return "", errors.New("Host Id cannot be empty")
+	}
+	nfsShare, err := f.FindNFSShareByID(ctx, nfsShareID)
+	if err != nil {
+		return "", err
+	}
+
+	var access AccessType
+	for _, accessType := range []AccessType{NoAccessType, ReadWriteRootAccessType, ReadOnlyRootAccessType, ReadWriteAccessType, ReadOnlyAccessType} {
+		if containsHostID(getNFSShareHostIDs(nfsShare, accessType), hostID) {
+			access = accessType
+			break
+		}
+	}
+	if access == "" {
+		switch NFSShareDefaultAccess(strconv.Itoa(nfsShare.NFSShareContent.DefaultAccess)) {
+		case NoneDefaultAccess:
+			access = NoAccessType
+		case ReadOnlyDefaultAccess:
+			access = ReadOnlyAccessType
+		case ReadWriteDefaultAccess:
+			access = ReadWriteAccessType
+		case ReadOnlyRootDefaultAccess:
+			access = ReadOnlyRootAccessType
+		case ReadWriteRootDefaultAccess:
+			access = ReadWriteRootAccessType
+		default:
+			return "", fmt.Errorf("unknown default access %d of NFS Share %s", nfsShare.NFSShareContent.DefaultAccess, nfsShareID)
+		}
+	}
+
+	if nfsShare.NFSShareContent.IsReadOnly {
+		if access == ReadWriteAccessType {
+			access = ReadOnlyAccessType
+		} else if access == ReadWriteRootAccessType {
+			access = ReadOnlyRootAccessType
+		}
+	}
+	return access, nil
+}
+
+//containsHostID returns true if the host ID is present in the host IDs
+func containsHostID(hostIDs []string, hostID string) bool {
+	for _, id := range hostIDs {
+		if id == hostID {
+			return true
+		}
+	}
+	return false
+}
+
+//ModifyNFSShareHostAccess - Modify the host access on NFS Share.
+//The access list of the given access type is replaced with exactly the provided hosts (ReplaceHostAccessMode),
+//any host previously present in that list and not provided here loses that access.
+//Use ModifyNFSShareHostAccessWithMode with AppendHostAccessMode to add hosts to the existing list instead.
+func (f *filesystem) ModifyNFSShareHostAccess(ctx context.Context, filesystemID, nfsShareID string, hostIDs []string, accessType AccessType) error {
+	return f.ModifyNFSShareHostAccessWithMode(ctx, filesystemID, nfsShareID, hostIDs, accessType, ReplaceHostAccessMode)
+}
+
+//ModifyNFSShareHostAccessWithMode - Modify the host access on NFS Share using the given mode.
+//ReplaceHostAccessMode overwrites the access list of the given access type with the provided hosts.
+//AppendHostAccessMode reads the current access list of the share and merges the provided hosts into it.
+func (f *filesystem) ModifyNFSShareHostAccessWithMode(ctx context.Context, filesystemID, nfsShareID string, hostIDs []string, accessType AccessType, mode HostAccessMode) error {
+	log := f.client.getLogger(ctx)
+	if len(filesystemID) == 0 {
+		return errors.New("Filesystem Id cannot be empty")
+	}
+
+	if mode != ReplaceHostAccessMode && mode != AppendHostAccessMode {
+		return fmt.Errorf("invalid host access mode: %s", mode)
+	}
+
+	if !isValidAccessType(accessType) {
+		return fmt.Errorf("invalid access type: %s", accessType)
+	}
+
+	filesystemResp, err := f.FindFilesystemByID(ctx, filesystemID)
+	if err != nil {
+		return ErrorFilesystemNotFound
+	}
+	resourceID := filesystemResp.FileContent.StorageResource.ID
+
+	if mode == AppendHostAccessMode {
+		nfsShareResp, err := f.FindNFSShareByID(ctx, nfsShareID)
+		if err != nil {
+			return err
+		}
+		hostIDs = mergeHostIDs(getNFSShareHostIDs(nfsShareResp, accessType), hostIDs)
+	}
+
+	hostsIdsContent := []types.HostIDContent{}
+	for _, hostID := range hostIDs {
+		hostIDContent := types.HostIDContent{
+			ID: hostID,
+		}
+		hostsIdsContent = append(hostsIdsContent, hostIDContent)
 	}
 
 	nfsShareParameters := types.NFSShareParameters{}
 	if accessType == ReadOnlyAccessType {
-		nfsShareParameters.ReadOnlyHosts = &hostsIdsContent
+		nfsShareParameters.ReadOnlyHosts = &hostsIdsContent
+	} else if accessType == ReadWriteAccessType {
+		nfsShareParameters.ReadWriteHosts = &hostsIdsContent
+	} else if accessType == ReadOnlyRootAccessType {
+		nfsShareParameters.ReadOnlyRootAccessHosts = &hostsIdsContent
+	} else if accessType == ReadWriteRootAccessType {
+		nfsShareParameters.RootAccessHosts = &hostsIdsContent
+	} else if accessType == NoAccessType {
+		nfsShareParameters.NoAccessHosts = &hostsIdsContent
+	}
+
+	nfsShare := types.StorageResourceParam{
+		ID: nfsShareID,
+	}
+
+	nfsShareModifyContent := types.NFSShareModifyContent{
+		NFSShare:           &nfsShare,
+		NFSShareParameters: &nfsShareParameters,
+	}
+	nfsSharesModifyContent := []types.NFSShareModifyContent{nfsShareModifyContent}
+
+	nfsShareModifyReq := types.NFSShareModify{
+		NFSSharesModifyContent: &nfsSharesModifyContent,
+	}
+
+	err = f.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityModifyFilesystemURI, resourceID), nfsShareModifyReq, nil)
+	if err != nil {
+		return fmt.Errorf("modify NFS Share failed. Error: %v", err)
+	}
+	log.Debugf("Modify NFS share: %s successful. Hosts with access %s set to %v (mode: %s)", nfsShareID, accessType, hostIDs, mode)
+	return f.verifyNFSShareHostAccess(ctx, nfsShareID, hostIDs, accessType)
+}
+
+//ReconcileNFSShareAccess - Set the default access and all the host access lists of a NFS share (the four allow lists and
+//the no access deny list) in a single modify request, so that the share never goes through an intermediate access
+//state. A host can be present in only one of the lists.
+func (f *filesystem) ReconcileNFSShareAccess(ctx context.Context, filesystemID, nfsShareID string, desired types.NFSShareAccessSpec) error {
+	log := f.client.getLogger(ctx)
+	if len(filesystemID) == 0 {
+		return errors.New("Filesystem Id cannot be empty")
+	}
+	if len(nfsShareID) == 0 {
+		return errors.New("NFS Share Id cannot be empty")
+	}
+
+	switch NFSShareDefaultAccess(desired.DefaultAccess) {
+	case NoneDefaultAccess, ReadOnlyDefaultAccess, ReadWriteDefaultAccess, ReadOnlyRootDefaultAccess, ReadWriteRootDefaultAccess:
+	default:
+		return fmt.Errorf("invalid NFS share default access: %s", desired.DefaultAccess)
+	}
+
+	hostAccessTypes := make(map[string]AccessType)
+	hostLists := []struct {
+		accessType AccessType
+		hostIDs    []string
+	}{
+		{ReadOnlyAccessType, desired.ReadOnlyHosts},
+		{ReadWriteAccessType, desired.ReadWriteHosts},
+		{ReadOnlyRootAccessType, desired.ReadOnlyRootAccessHosts},
+		{ReadWriteRootAccessType, desired.RootAccessHosts},
+		{NoAccessType, desired.NoAccessHosts},
+	}
+	for _, hostList := range hostLists {
+		for _, hostID := range hostList.hostIDs {
+			if accessType, ok := hostAccessTypes[hostID]; ok && accessType != hostList.accessType {
+				return fmt.Errorf("host %s cannot have both %s and %s access", hostID, accessType, hostList.accessType)
+			}
+			hostAccessTypes[hostID] = hostList.accessType
+		}
+	}
+
+	filesystemResp, err := f.FindFilesystemByID(ctx, filesystemID)
+	if err != nil {
+		return err
+	}
+	resourceID := filesystemResp.FileContent.StorageResource.ID
+
+	readOnlyHosts := hostIDContents(desired.ReadOnlyHosts)
+	readWriteHosts := hostIDContents(desired.ReadWriteHosts)
+	readOnlyRootAccessHosts := hostIDContents(desired.ReadOnlyRootAccessHosts)
+	rootAccessHosts := hostIDContents(desired.RootAccessHosts)
+	noAccessHosts := hostIDContents(desired.NoAccessHosts)
+	nfsShareParameters := types.NFSShareParameters{
+		DefaultAccess:           desired.DefaultAccess,
+		ReadOnlyHosts:           &readOnlyHosts,
+		ReadWriteHosts:          &readWriteHosts,
+		ReadOnlyRootAccessHosts: &readOnlyRootAccessHosts,
+		RootAccessHosts:         &rootAccessHosts,
+		NoAccessHosts:           &noAccessHosts,
+	}
+
+	nfsShareModifyContent := types.NFSShareModifyContent{
+		NFSShare:           &types.StorageResourceParam{ID: nfsShareID},
+		NFSShareParameters: &nfsShareParameters,
+	}
+	nfsShareModifyReq := types.NFSShareModify{
+		NFSSharesModifyContent: &[]types.NFSShareModifyContent{nfsShareModifyContent},
+	}
+
+	err = f.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityModifyFilesystemURI, resourceID), nfsShareModifyReq, nil)
+	if err != nil {
+		return fmt.Errorf("reconcile NFS Share %s access failed. Error: %v", nfsShareID, err)
+	}
+	log.Debugf("Reconcile NFS share: %s access to %+v successful", nfsShareID, desired)
+	return nil
+}
+
+//ModifyFilesystemNFSDefaults - Apply the given default access to all the NFS shares of the filesystem in a single modify.
+//Unity has no filesystem wide NFS settings, the access is set per share, so shares created afterwards are not affected.
+//With rootSquash, root access is revoked on all the shares: the hosts with root access are moved to the corresponding
+//non root access list, and a root default access is rejected. Shares of snapshots are left unchanged.
+func (f *filesystem) ModifyFilesystemNFSDefaults(ctx context.Context, filesystemID string, defaultAccess NFSShareDefaultAccess, rootSquash bool) error {
+	switch defaultAccess {
+	case NoneDefaultAccess, ReadOnlyDefaultAccess, ReadWriteDefaultAccess:
+	case ReadOnlyRootDefaultAccess, ReadWriteRootDefaultAccess:
+		if rootSquash {
+			return fmt.Errorf("NFS share default access: %s grants root access and cannot be used with root squash", defaultAccess)
+		}
+	default:
+		return fmt.Errorf("invalid NFS share default access: %s", defaultAccess)
+	}
+
+	filesystemResp, err := f.FindFilesystemByID(ctx, filesystemID)
+	if err != nil {
+		return err
+	}
+
+	var nfsSharesModifyContent []types.NFSShareModifyContent
+	for _, share := range filesystemResp.FileContent.NFSShare {
+		if share.ParentSnap.ID != "" {
+			continue
+		}
+		nfsShareResp, err := f.FindNFSShareByID(ctx, share.ID)
+		if err != nil {
+			return err
+		}
+		content := nfsShareResp.NFSShareContent
+		readOnlyHosts := hostIDContentsOf(content.ReadOnlyHosts)
+		readWriteHosts := hostIDContentsOf(content.ReadWriteHosts)
+		readOnlyRootAccessHosts := hostIDContentsOf(content.ReadOnlyRootAccessHosts)
+		rootAccessHosts := hostIDContentsOf(content.RootAccessHosts)
+		if rootSquash {
+			readOnlyHosts = append(readOnlyHosts, readOnlyRootAccessHosts...)
+			readWriteHosts = append(readWriteHosts, rootAccessHosts...)
+			readOnlyRootAccessHosts = []types.HostIDContent{}
+			rootAccessHosts = []types.HostIDContent{}
+		}
+		nfsSharesModifyContent = append(nfsSharesModifyContent, types.NFSShareModifyContent{
+			NFSShare: &types.StorageResourceParam{ID: share.ID},
+			NFSShareParameters: &types.NFSShareParameters{
+				DefaultAccess:           string(defaultAccess),
+				ReadOnlyHosts:           &readOnlyHosts,
+				ReadWriteHosts:          &readWriteHosts,
+				ReadOnlyRootAccessHosts: &readOnlyRootAccessHosts,
+				RootAccessHosts:         &rootAccessHosts,
+			},
+		})
+	}
+	if len(nfsSharesModifyContent) == 0 {
+		return nil
+	}
+
+	nfsShareModifyReq := types.NFSShareModify{
+		NFSSharesModifyContent: &nfsSharesModifyContent,
+	}
+	err = f.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityModifyFilesystemURI, filesystemResp.FileContent.StorageResource.ID), nfsShareModifyReq, nil)
+	if err != nil {
+		return fmt.Errorf("modify filesystem: %s NFS defaults failed. Error: %v", filesystemID, err)
+	}
+	return nil
+}
+
+//hostIDContentsOf converts the hosts of a NFS share access list to host ID contents, always returning a non nil slice
+func hostIDContentsOf(hosts []types.HostContent) []types.HostIDContent {
+	hostsIdsContent := []types.HostIDContent{}
+	for _, host := range hosts {
+		hostsIdsContent = append(hostsIdsContent, types.HostIDContent{ID: host.ID})
+	}
+	return hostsIdsContent
+}
+
+//hostIDContents converts the host IDs to host ID contents, always returning a non nil slice
+func hostIDContents(hostIDs []string) []types.HostIDContent {
+	hostsIdsContent := []types.HostIDContent{}
+	for _, hostID := range hostIDs {
+		hostsIdsContent = append(hostsIdsContent, types.HostIDContent{ID: hostID})
+	}
+	return hostsIdsContent
+}
+
+//RemoveNFSShareHostAccess - Remove the given hosts from the access list of the given access type of a NFS share.
+//Hosts not present in the access list are ignored.
+func (f *filesystem) RemoveNFSShareHostAccess(ctx context.Context, filesystemID, nfsShareID string, hostIDs []string, accessType AccessType) error {
+	if len(nfsShareID) == 0 {
+		return errors.New("NFS Share Id cannot be empty")
+	}
+
+	if !isValidAccessType(accessType) {
+		return fmt.Errorf("invalid access type: %s", accessType)
+	}
+
+	nfsShareResp, err := f.FindNFSShareByID(ctx, nfsShareID)
+	if err != nil {
+		return err
+	}
+
+	remainingHostIDs := removeHostIDs(getNFSShareHostIDs(nfsShareResp, accessType), hostIDs)
+	return f.ModifyNFSShareHostAccessWithMode(ctx, filesystemID, nfsShareID, remainingHostIDs, accessType, ReplaceHostAccessMode)
+}
+
+//GrantClusterAccess - Grant the given access on the NFS Share to all the hosts of a cluster. Each host is looked up by
+//name and created if missing, with the IP addresses it lacks added, then the hosts are added to the access list of
+//the share in a single modify (AppendHostAccessMode), keeping the hosts already granted.
+func (f *filesystem) GrantClusterAccess(ctx context.Context, filesystemID, nfsShareID string, hostSpecs []types.HostSpec, accessType AccessType) error {
+	log := f.client.getLogger(ctx)
+	if len(hostSpecs) == 0 {
+		return errors.New("host specs shouldn't be empty")
+	}
+	for _, spec := range hostSpecs {
+		for _, address := range spec.IPAddresses {
+			if _, err := util.ParseIPAddress(address); err != nil {
+				return fmt.Errorf("invalid IP address %s of host %s. Error: %v", address, spec.Name, err)
+			}
+		}
+	}
+
+	hostAPI := NewHost(f.client)
+	var hostIDs []string
+	for _, spec := range hostSpecs {
+		host, err := hostAPI.FindHostByName(ctx, spec.Name)
+		if err == ErrorHostNotFound {
+			log.Debugf("Host %s not found, creating it", spec.Name)
+			host, err = hostAPI.CreateHost(ctx, spec.Name, spec.TenantID)
+		}
+		if err != nil {
+			return fmt.Errorf("unable to find or create host: %s. Error: %v", spec.Name, err)
+		}
+		hostID := host.HostContent.ID
+
+		existing := make(map[string]bool)
+		for _, ipPort := range host.HostContent.IPPorts {
+			address := ipPort.Address
+			if address == "" {
+				hostIPPort, err := hostAPI.FindHostIPPortByID(ctx, ipPort.ID)
+				if err != nil {
+					return err
+				}
+				address = hostIPPort.HostIPContent.Address
+			}
+			existing[address] = true
+		}
+		for _, address := range spec.IPAddresses {
+			if existing[address] {
+				continue
+			}
+			if _, err = hostAPI.CreateHostIPPort(ctx, hostID, address); err != nil {
+				return fmt.Errorf("unable to add IP address %s to host: %s. Error: %v", address, spec.Name, err)
+			}
+		}
+		hostIDs = append(hostIDs, hostID)
+	}
+
+	return f.ModifyNFSShareHostAccessWithMode(ctx, filesystemID, nfsShareID, hostIDs, accessType, AppendHostAccessMode)
+}
+
+//verifyNFSShareHostAccess reads the NFS share back and checks the access list of the access type holds exactly the host IDs
+func (f *filesystem) verifyNFSShareHostAccess(ctx context.Context, nfsShareID string, hostIDs []string, accessType AccessType) error {
+	if f.skipVerificationRead {
+		return nil
+	}
+	nfsShareResp, err := f.FindNFSShareByID(ctx, nfsShareID)
+	if err != nil {
+		return fmt.Errorf("unable to read NFS Share: %s after modify. Error: %v", nfsShareID, err)
+	}
+	actualHostIDs := getNFSShareHostIDs(nfsShareResp, accessType)
+	if len(removeHostIDs(actualHostIDs, hostIDs)) != 0 || len(removeHostIDs(hostIDs, actualHostIDs)) != 0 {
+		return fmt.Errorf("NFS Share: %s hosts with access %s are %v after modify, expected %v", nfsShareID, accessType, actualHostIDs, hostIDs)
+	}
+	return nil
+}
+
+//SetSkipVerificationRead - By default the NFS share is read back after it is created, modified or deleted, so that
+//the callers observe a consistent state. Skipping the read saves a request per operation.
+func (f *filesystem) SetSkipVerificationRead(skip bool) {
+	f.skipVerificationRead = skip
+}
+
+//getNFSShareHostIDs returns the IDs of the hosts present in the access list of the given access type
+func getNFSShareHostIDs(nfsShare *types.NFSShare, accessType AccessType) []string {
+	var hosts []types.HostContent
+	if accessType == ReadOnlyAccessType {
+		hosts = nfsShare.NFSShareContent.ReadOnlyHosts
 	} else if accessType == ReadWriteAccessType {
-		nfsShareParameters.ReadWriteHosts = &hostsIdsContent
+		hosts = nfsShare.NFSShareContent.ReadWriteHosts
 	} else if accessType == ReadOnlyRootAccessType {
-		nfsShareParameters.ReadOnlyRootAccessHosts = &hostsIdsContent
+		hosts = nfsShare.NFSShareContent.ReadOnlyRootAccessHosts
 	} else if accessType == ReadWriteRootAccessType {
-		nfsShareParameters.RootAccessHosts = &hostsIdsContent
+		hosts = nfsShare.NFSShareContent.RootAccessHosts
+	} else if accessType == NoAccessType {
+		hosts = nfsShare.NFSShareContent.NoAccessHosts
 	}
 
-	nfsShare := types.StorageResourceParam{
-		ID: nfsShareID,
+	hostIDs := []string{}
+	for _, host := range hosts {
+		hostIDs = append(hostIDs, host.ID)
 	}
+	return hostIDs
+}
 
-	nfsShareModifyContent := types.NFSShareModifyContent{
-		NFSShare:           &nfsShare,
-		NFSShareParameters: &nfsShareParameters,
+//isValidAccessType returns true for the access types of the NFS share host access lists
+func isValidAccessType(accessType AccessType) bool {
+	switch accessType {
+	case ReadOnlyAccessType, ReadWriteAccessType, ReadOnlyRootAccessType, ReadWriteRootAccessType, NoAccessType:
+		return true
 	}
-	nfsSharesModifyContent := []types.NFSShareModifyContent{nfsShareModifyContent}
+	return false
+}
 
-	nfsShareModifyReq := types.NFSShareModify{
-		NFSSharesModifyContent: &nfsSharesModifyContent,
+//removeHostIDs returns the existing host IDs which are not present in the removed ones
+func removeHostIDs(existing, removed []string) []string {
+	removedSet := make(map[string]bool)
+	for _, hostID := range removed {
+		removedSet[hostID] = true
 	}
 
-	err = f.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityModifyFilesystemURI, resourceID), nfsShareModifyReq, nil)
-	if err != nil {
-		return fmt.Errorf("modify NFS Share failed. Error: %v", err)
+	remaining := []string{}
+	for _, hostID := range existing {
+		if !removedSet[hostID] {
+			remaining = append(remaining, hostID)
+		}
 	}
-	log.Debugf("Modify NFS share: %s successful. Added host with access %s", nfsShareID, accessType)
-	return nil
+	return remaining
+}
+
+//mergeHostIDs appends the additional host IDs to the existing ones, skipping duplicates
+func mergeHostIDs(existing, additional []string) []string {
+	merged := []string{}
+	seen := make(map[string]bool)
+	for _, hostID := range append(existing, additional...) {
+		if !seen[hostID] {
+			seen[hostID] = true
+			merged = append(merged, hostID)
+		}
+	}
+	return merged
 }
 
 //ModifyNFSShareCreatedFromSnapshotHostAccess - Modify the host access on NFS Share
-func (f *Filesystem) ModifyNFSShareCreatedFromSnapshotHostAccess(ctx context.Context, nfsShareID string, hostIDs []string, accessType AccessType) error {
+func (f *filesystem) ModifyNFSShareCreatedFromSnapshotHostAccess(ctx context.Context, nfsShareID string, hostIDs []string, accessType AccessType) error {
 	if nfsShareID == "" {
 		return errors.New("NFS Share Id cannot be empty")
 	}
@@ -426,6 +1812,8 @@ func (f *Filesystem) ModifyNFSShareCreatedFromSnapshotHostAccess(ctx context.Con
 		nfsShareModifyReq.ReadOnlyRootAccessHosts = &hostsIdsContent
 	} else if accessType == ReadWriteRootAccessType {
 		nfsShareModifyReq.RootAccessHosts = &hostsIdsContent
+	} else if accessType == NoAccessType {
+		nfsShareModifyReq.NoAccessHosts = &hostsIdsContent
 	}
 
 	err := f.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityModifyNFSShareURI, api.NfsShareAction, nfsShareID), nfsShareModifyReq, nil)
@@ -435,9 +1823,70 @@ func (f *Filesystem) ModifyNFSShareCreatedFromSnapshotHostAccess(ctx context.Con
 	return nil
 }
 
-//DeleteNFSShare by its ID. If the NFSShare is not present on the array, an error will be returned.
-func (f *Filesystem) DeleteNFSShare(ctx context.Context, filesystemID, nfsShareID string) error {
-	log := util.GetRunIDLogger(ctx)
+//ModifySnapshotNFSShareHostAccess - Modify the host access of a NFS share created from the given snapshot. Unlike the
+//shares of a filesystem, the share is modified directly since it's parent is the snapshot and not a storage resource.
+func (f *filesystem) ModifySnapshotNFSShareHostAccess(ctx context.Context, snapshotID, nfsShareID string, hostIDs []string, accessType AccessType) error {
+	if snapshotID == "" {
+		return errors.New("Snapshot Id cannot be empty")
+	}
+	if nfsShareID == "" {
+		return errors.New("NFS Share Id cannot be empty")
+	}
+
+	nfsShareResp, err := f.FindNFSShareByID(ctx, nfsShareID, "id", "snap")
+	if err != nil {
+		return err
+	}
+	if nfsShareResp.NFSShareContent.Snapshot.ID != snapshotID {
+		return fmt.Errorf("NFS Share %s is not created from snapshot %s", nfsShareID, snapshotID)
+	}
+	return f.ModifyNFSShareCreatedFromSnapshotHostAccess(ctx, nfsShareID, hostIDs, accessType)
+}
+
+//PromoteSnapshotShareToReadWrite - Make a NFS share created from a snapshot writable. The snapshot must have been created
+//with protocol access, checkpoint snapshots are read-only and ErrorSnapshotNotWritable is returned for them.
+func (f *filesystem) PromoteSnapshotShareToReadWrite(ctx context.Context, nfsShareID string) error {
+	log := f.client.getLogger(ctx)
+	if nfsShareID == "" {
+		return errors.New("NFS Share Id cannot be empty")
+	}
+
+	nfsShareResp, err := f.FindNFSShareByID(ctx, nfsShareID)
+	if err != nil {
+		return err
+	}
+	snapshotID := nfsShareResp.NFSShareContent.Snapshot.ID
+	if snapshotID == "" {
+		return fmt.Errorf("NFS Share %s is not created from a snapshot", nfsShareID)
+	}
+	if !nfsShareResp.NFSShareContent.IsReadOnly {
+		log.Debugf("NFS Share %s is already read-write", nfsShareID)
+		return nil
+	}
+
+	snapshotResp, err := NewSnapshot(f.client).FindSnapshotByID(ctx, snapshotID)
+	if err != nil {
+		return err
+	}
+	if snapshotResp.SnapshotContent.AccessType != int(ProtocolAccessType) {
+		return fmt.Errorf("promote NFS Share %s failed for snapshot %s: %w", nfsShareID, snapshotID, ErrorSnapshotNotWritable)
+	}
+
+	isReadOnly := false
+	nfsShareModifyReq := types.NFSShareCreateFromSnapModify{
+		IsReadOnly: &isReadOnly,
+	}
+	err = f.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityModifyNFSShareURI, api.NfsShareAction, nfsShareID), nfsShareModifyReq, nil)
+	if err != nil {
+		return fmt.Errorf("promote NFS Share %s to read-write failed. Error: %v", nfsShareID, err)
+	}
+	log.Debugf("NFS Share %s promoted to read-write", nfsShareID)
+	return nil
+}
+
+//DeleteNFSShare by its ID. If the NFSShare is not present on the array, it is treated as already deleted and nil is returned.
+func (f *filesystem) DeleteNFSShare(ctx context.Context, filesystemID, nfsShareID string) error {
+	log := f.client.getLogger(ctx)
 
 	if len(filesystemID) == 0 {
 		return errors.New("Filesystem Id cannot be empty")
@@ -453,6 +1902,10 @@ func (f *Filesystem) DeleteNFSShare(ctx context.Context, filesystemID, nfsShareI
 	}
 	_, err = f.FindNFSShareByID(ctx, nfsShareID)
 	if err != nil {
+		if err == ErrorNFSShareNotFound {
+			log.Infof("NFS Share: %s not found. Assuming it is already deleted", nfsShareID)
+			return nil
+		}
 		return fmt.Errorf("unable to find NFS Share. Error: %v", err)
 	}
 
@@ -471,14 +1924,57 @@ func (f *Filesystem) DeleteNFSShare(ctx context.Context, filesystemID, nfsShareI
 
 	deleteErr := f.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityModifyFilesystemURI, resourceID), nfsShareDeleteReq, nil)
 	if deleteErr != nil {
+		if strings.Contains(deleteErr.Error(), NFSShareNotFoundErrorCode) {
+			log.Infof("NFS Share: %s not found. Assuming it is already deleted", nfsShareID)
+			return nil
+		}
 		return fmt.Errorf("delete NFS Share: %s Failed. Error: %v", nfsShareID, deleteErr)
 	}
+	if !f.skipVerificationRead {
+		if _, err = f.FindNFSShareByID(ctx, nfsShareID); err != ErrorNFSShareNotFound {
+			return fmt.Errorf("NFS Share: %s still found after delete. Error: %v", nfsShareID, err)
+		}
+	}
 	log.Infof("Delete NFS Share: %s Successful", nfsShareID)
 	return nil
 }
 
+//DeleteAllNFSShares - Delete all the NFS shares of the filesystem, including the shares of it's snapshots, Ex: before
+//deleting the filesystem. Shares already deleted are ignored and a failure doesn't stop the deletion of the remaining
+//shares, the failures are reported together. The deletion stops when the context is cancelled.
+func (f *filesystem) DeleteAllNFSShares(ctx context.Context, filesystemID string) error {
+	if len(filesystemID) == 0 {
+		return errors.New("Filesystem Id cannot be empty")
+	}
+	sharesResp := &types.ListNFSShares{}
+	err := f.client.QueryInstances(ctx, api.NfsShareAction, []string{"id", "snap"}, fmt.Sprintf("filesystem.id eq \"%s\"", filesystemID), sharesResp)
+	if err != nil {
+		return fmt.Errorf("unable to list NFS Shares of filesystem: %s. Error: %v", filesystemID, err)
+	}
+
+	var failures []string
+	for _, share := range sharesResp.NFSShares {
+		if err = ctx.Err(); err != nil {
+			failures = append(failures, err.Error())
+			break
+		}
+		if share.NFSShareContent.Snapshot.ID != "" {
+			err = f.DeleteNFSShareCreatedFromSnapshot(ctx, share.NFSShareContent.ID)
+		} else {
+			err = f.DeleteNFSShare(ctx, filesystemID, share.NFSShareContent.ID)
+		}
+		if err != nil && !strings.Contains(err.Error(), NFSShareNotFoundErrorCode) {
+			failures = append(failures, err.Error())
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("unable to delete all NFS Shares of filesystem: %s. Error: %s", filesystemID, strings.Join(failures, "; "))
+	}
+	return nil
+}
+
 //DeleteNFSShareCreatedFromSnapshot by its ID. If the NFSShare is not present on the array, an error will be returned.
-func (f *Filesystem) DeleteNFSShareCreatedFromSnapshot(ctx context.Context, nfsShareID string) error {
+func (f *filesystem) DeleteNFSShareCreatedFromSnapshot(ctx context.Context, nfsShareID string) error {
 	if len(nfsShareID) == 0 {
 		return errors.New("NFS Share Id cannot be empty")
 	}
@@ -496,7 +1992,7 @@ func (f *Filesystem) DeleteNFSShareCreatedFromSnapshot(ctx context.Context, nfsS
 }
 
 //FindNASServerByID - Find the NAS Server by it's Id. If the NAS Server is not found, an error will be returned.
-func (f *Filesystem) FindNASServerByID(ctx context.Context, nasServerID string) (*types.NASServer, error) {
+func (f *filesystem) FindNASServerByID(ctx context.Context, nasServerID string) (*types.NASServer, error) {
 	if len(nasServerID) == 0 {
 		return nil, errors.New("NAS Server Id shouldn't be empty")
 	}
@@ -508,9 +2004,211 @@ func (f *Filesystem) FindNASServerByID(ctx context.Context, nasServerID string)
 	return nasServerResp, nil
 }
 
+//DeleteNASServer - Delete the NAS Server. The array refuses to delete a NAS server still having filesystems or file
+//interfaces. With force, these are deleted first in the order required by the array: the filesystems (along with their
+//NFS shares) when deleteFilesystems is set, then the file interfaces. The deletion stops at the first step which fails,
+//with ErrNASServerDeletionBlocked naming the resources blocking it.
+func (f *filesystem) DeleteNASServer(ctx context.Context, nasServerID string, force, deleteFilesystems bool) error {
+	log := f.client.getLogger(ctx)
+	if len(nasServerID) == 0 {
+		return errors.New("NAS Server Id shouldn't be empty")
+	}
+
+	if force {
+		if err := f.deleteNASServerFilesystems(ctx, nasServerID, deleteFilesystems); err != nil {
+			return err
+		}
+		if err := f.deleteNASServerFileInterfaces(ctx, nasServerID); err != nil {
+			return err
+		}
+	}
+
+	err := f.client.executeWithRetryAuthenticate(ctx, http.MethodDelete, fmt.Sprintf(api.UnityAPIGetResourceURI, api.NasServerAction, nasServerID), nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete NAS Server: %s Failed. Error: %v", nasServerID, err)
+	}
+	log.Debugf("Delete NAS Server %s Successful", nasServerID)
+	return nil
+}
+
+//deleteNASServerFilesystems deletes the filesystems of the NAS server when deleteFilesystems is set and returns
+//ErrNASServerDeletionBlocked naming the filesystems left (Ex: marked for deletion as they have snapshots)
+func (f *filesystem) deleteNASServerFilesystems(ctx context.Context, nasServerID string, deleteFilesystems bool) error {
+	filter := fmt.Sprintf("nasServer.id eq \"%s\"", nasServerID)
+	filesystems := &types.ListFilesystems{}
+	if err := f.client.QueryInstances(ctx, api.FileSystemAction, []string{"id", "name"}, filter, filesystems); err != nil {
+		return fmt.Errorf("unable to list filesystems of NAS Server: %s. Error: %w", nasServerID, err)
+	}
+	if len(filesystems.Filesystems) == 0 {
+		return nil
+	}
+
+	blocking := []string{}
+	for _, filesystem := range filesystems.Filesystems {
+		content := filesystem.FileContent
+		if !deleteFilesystems {
+			blocking = append(blocking, fmt.Sprintf("filesystem %s (%s)", content.Name, content.ID))
+			continue
+		}
+		if err := f.DeleteFilesystem(ctx, content.ID); err != nil {
+			blocking = append(blocking, fmt.Sprintf("filesystem %s (%s). Error: %v", content.Name, content.ID, err))
+		}
+	}
+	if len(blocking) == 0 {
+		filesystems = &types.ListFilesystems{}
+		if err := f.client.QueryInstances(ctx, api.FileSystemAction, []string{"id", "name"}, filter, filesystems); err != nil {
+			return fmt.Errorf("unable to list filesystems of NAS Server: %s. Error: %w", nasServerID, err)
+		}
+		for _, filesystem := range filesystems.Filesystems {
+			blocking = append(blocking, fmt.Sprintf("filesystem %s (%s) still exists after delete", filesystem.FileContent.Name, filesystem.FileContent.ID))
+		}
+	}
+	return nasServerDeletionBlocked(nasServerID, blocking)
+}
+
+//deleteNASServerFileInterfaces deletes the file interfaces of the NAS server and returns ErrNASServerDeletionBlocked
+//naming the file interfaces which could not be deleted
+func (f *filesystem) deleteNASServerFileInterfaces(ctx context.Context, nasServerID string) error {
+	interfaces, err := NewIPInterface(f.client).listFileInterfaces(ctx, nasServerID)
+	if err != nil {
+		return err
+	}
+	blocking := []string{}
+	for _, fileInterface := range interfaces {
+		content := fileInterface.FileInterfaceContent
+		err := f.client.executeWithRetryAuthenticate(ctx, http.MethodDelete, fmt.Sprintf(api.UnityAPIGetResourceURI, api.FileInterfaceAction, content.ID), nil, nil)
+		if err != nil {
+			blocking = append(blocking, fmt.Sprintf("file interface %s (%s). Error: %v", content.IPAddress, content.ID, err))
+		}
+	}
+	return nasServerDeletionBlocked(nasServerID, blocking)
+}
+
+//nasServerDeletionBlocked returns ErrNASServerDeletionBlocked listing the blocking resources, nil if there are none
+func nasServerDeletionBlocked(nasServerID string, blocking []string) error {
+	if len(blocking) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s blocked by: %s", ErrNASServerDeletionBlocked, nasServerID, strings.Join(blocking, "; "))
+}
+
+//SetNASServerDNS - Configure the DNS domain and servers used by the NAS server, which resolves the client host names of
+//the NFS exports. The DNS configuration of the NAS server is replaced if any, created otherwise.
+func (f *filesystem) SetNASServerDNS(ctx context.Context, nasServerID, domain string, addresses []string) error {
+	nasServer, err := f.findNASServerForNameService(ctx, nasServerID, domain, addresses)
+	if err != nil {
+		return err
+	}
+	err = f.setNASServerNameService(ctx, api.FileDNSServerAction, nasServerID, nasServer.NASServerContent.FileDNSServer.ID, domain, addresses)
+	if err != nil {
+		return fmt.Errorf("unable to set DNS of NAS Server: %s. Error: %v", nasServerID, err)
+	}
+	return nil
+}
+
+//SetNASServerNIS - Configure the NIS domain and servers used by the NAS server to resolve host names, users and netgroups.
+//The NIS configuration of the NAS server is replaced if any, created otherwise.
+func (f *filesystem) SetNASServerNIS(ctx context.Context, nasServerID, domain string, addresses []string) error {
+	nasServer, err := f.findNASServerForNameService(ctx, nasServerID, domain, addresses)
+	if err != nil {
+		return err
+	}
+	err = f.setNASServerNameService(ctx, api.FileNISServerAction, nasServerID, nasServer.NASServerContent.FileNISServer.ID, domain, addresses)
+	if err != nil {
+		return fmt.Errorf("unable to set NIS of NAS Server: %s. Error: %v", nasServerID, err)
+	}
+	return nil
+}
+
+//findNASServerForNameService validates the name service domain & addresses and returns the NAS server
+func (f *filesystem) findNASServerForNameService(ctx context.Context, nasServerID, domain string, addresses []string) (*types.NASServer, error) {
+	if domain == "" {
+		return nil, errors.New("domain should not be empty")
+	}
+	if len(addresses) == 0 {
+		return nil, errors.New("at least one server address should be given")
+	}
+	for _, address := range addresses {
+		if _, err := util.ParseIPAddress(address); err != nil {
+			return nil, fmt.Errorf("invalid server address %s. Error: %v", address, err)
+		}
+	}
+	return f.FindNASServerByID(ctx, nasServerID)
+}
+
+//setNASServerNameService modifies the given name service (fileDNSServer or fileNISServer) instance, or creates one for the NAS server if serverID is empty
+func (f *filesystem) setNASServerNameService(ctx context.Context, resType, nasServerID, serverID, domain string, addresses []string) error {
+	param := types.FileNameServerParam{
+		Domain:    domain,
+		Addresses: addresses,
+	}
+	if serverID != "" {
+		return f.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityModifyNameServerURI, resType, serverID), param, nil)
+	}
+	param.NASServer = &types.StorageResourceParam{ID: nasServerID}
+	return f.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityAPIInstanceTypeResources, resType), param, nil)
+}
+
+//checkKerberosConfigured returns ErrKerberosNotConfigured if the NFS server of the NAS server has no secure NFS
+//(Kerberos) enabled, or if it uses the Windows KDC without a SMB server joined to the AD domain
+func (f *filesystem) checkKerberosConfigured(ctx context.Context, nasServerID string) error {
+	nasServer, err := f.FindNASServerByID(ctx, nasServerID)
+	if err != nil {
+		return err
+	}
+	nfsServerID := nasServer.NASServerContent.NFSServer.ID
+	if nfsServerID == "" {
+		return fmt.Errorf("%w: NAS server %s has no NFS server", ErrKerberosNotConfigured, nasServerID)
+	}
+	nfsServerResp := &types.NFSServerInstance{}
+	err = f.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceWithFieldsURI, api.NfsServerAction, nfsServerID, NFSServerSecurityFields), nil, nfsServerResp)
+	if err != nil {
+		return fmt.Errorf("unable to find NFS Server: %s of NAS Server: %s. Error: %v", nfsServerID, nasServerID, err)
+	}
+	nfsServer := nfsServerResp.NFSServer
+	if !nfsServer.IsSecureEnabled {
+		return fmt.Errorf("%w: secure NFS is not enabled on NFS server %s of NAS server %s", ErrKerberosNotConfigured, nfsServerID, nasServerID)
+	}
+	if nfsServer.KdcType == KdcTypeWindows && len(nasServer.NASServerContent.CIFSServers) == 0 {
+		return fmt.Errorf("%w: NAS server %s uses the Windows KDC without a SMB server joined to the AD domain", ErrKerberosNotConfigured, nasServerID)
+	}
+	return nil
+}
+
+//checkNFSServerConfigured returns ErrNFSServerNotConfigured if the NAS server has no NFS server with NFSv3 or NFSv4 enabled
+func (f *filesystem) checkNFSServerConfigured(ctx context.Context, nasServerID string) error {
+	nasServer, err := f.FindNASServerByID(ctx, nasServerID)
+	if err != nil {
+		return err
+	}
+	nfsServer := nasServer.NASServerContent.NFSServer
+	if nfsServer.ID == "" || (!nfsServer.NFSv3Enabled && !nfsServer.NFSv4Enabled) {
+		return fmt.Errorf("%w: %s", ErrNFSServerNotConfigured, nasServerID)
+	}
+	return nil
+}
+
+//ModifyFilesystemThinProvisioning - Unity sets thin provisioning when the filesystem is created and cannot convert it in place,
+//so ErrThinConversionNotSupported is returned unless the filesystem already has the requested provisioning.
+//To change it, create a new filesystem with the requested provisioning and copy the data over (Ex: host based copy).
+func (f *filesystem) ModifyFilesystemThinProvisioning(ctx context.Context, filesystemID string, isThinEnabled bool) error {
+	if len(filesystemID) == 0 {
+		return errors.New("Filesystem Id cannot be empty")
+	}
+	filesystemResp, err := f.FindFilesystemByID(ctx, filesystemID)
+	if err != nil {
+		return err
+	}
+	if filesystemResp.FileContent.IsThinEnabled == isThinEnabled {
+		return nil
+	}
+	return fmt.Errorf("modify filesystem: %s thin provisioning to %t failed: %w", filesystemID, isThinEnabled, ErrThinConversionNotSupported)
+}
+
 //ExpandFilesystem Filesystem Expand volume to provided capacity
-func (f *Filesystem) ExpandFilesystem(ctx context.Context, filesystemID string, newSize uint64) error {
-	log := util.GetRunIDLogger(ctx)
+//Only the size is sent in the modify request, so the NFS shares and their host access lists are left untouched.
+func (f *filesystem) ExpandFilesystem(ctx context.Context, filesystemID string, newSize uint64) error {
+	log := f.client.getLogger(ctx)
 	filesystem, err := f.FindFilesystemByID(ctx, filesystemID)
 	if err != nil {
 		return fmt.Errorf("unable to find filesystem Id %s. Error: %v", filesystemID, err)
@@ -529,3 +2227,185 @@ func (f *Filesystem) ExpandFilesystem(ctx context.Context, filesystemID string,
 	}
 	return f.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityModifyFilesystemURI, filesystem.FileContent.StorageResource.ID), fsExpandReqParam, nil)
 }
+
+//SetFilesystemAutoExtend - Set the policy growing the filesystem once it's used space reaches highWatermark percent of
+//it's size, up to maxSize. Unity has no auto extension of filesystems (no fsParameters for it), so the policy is only
+//stored in the filesystem tags, i.e. the filesystem description visible to the array users is rewritten with the tags
+//appended (see SetFilesystemTags). The filesystem is not extended here: the policy takes effect only when the caller
+//runs ApplyFilesystemAutoExtend, which should be called periodically. A maxSize of 0 removes the policy.
+//Growing a thin filesystem consumes no pool capacity until the space is written, so the pool can get oversubscribed;
+//the pool utilization should be monitored (Ex: ListPoolsAboveUtilization).
+func (f *filesystem) SetFilesystemAutoExtend(ctx context.Context, filesystemID string, maxSize uint64, highWatermark int) error {
+	tags, err := f.GetFilesystemTags(ctx, filesystemID)
+	if err != nil {
+		return err
+	}
+	if maxSize == 0 {
+		delete(tags, AutoExtendMaxSizeTag)
+		delete(tags, AutoExtendHighWatermarkTag)
+		return f.SetFilesystemTags(ctx, filesystemID, tags)
+	}
+	if highWatermark < 1 || highWatermark > 99 {
+		return fmt.Errorf("auto extend high watermark %d should be in between 1-99", highWatermark)
+	}
+	filesystemResp, err := f.FindFilesystemByID(ctx, filesystemID)
+	if err != nil {
+		return err
+	}
+	if maxSize < filesystemResp.FileContent.SizeTotal {
+		return fmt.Errorf("auto extend max size %d is smaller than the filesystem size %d", maxSize, filesystemResp.FileContent.SizeTotal)
+	}
+
+	tags[AutoExtendMaxSizeTag] = strconv.FormatUint(maxSize, 10)
+	tags[AutoExtendHighWatermarkTag] = strconv.Itoa(highWatermark)
+	return f.SetFilesystemTags(ctx, filesystemID, tags)
+}
+
+//ApplyFilesystemAutoExtend - Apply the policy set by SetFilesystemAutoExtend: once the used space reaches the high
+//watermark, the filesystem size is doubled, up to the max size. Nothing is done for a filesystem without policy or
+//below the high watermark.
+func (f *filesystem) ApplyFilesystemAutoExtend(ctx context.Context, filesystemID string) error {
+	log := f.client.getLogger(ctx)
+	tags, err := f.GetFilesystemTags(ctx, filesystemID)
+	if err != nil {
+		return err
+	}
+	if tags[AutoExtendMaxSizeTag] == "" {
+		return nil
+	}
+	maxSize, err := strconv.ParseUint(tags[AutoExtendMaxSizeTag], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid auto extend max size of filesystem: %s. Error: %v", filesystemID, err)
+	}
+	highWatermark, err := strconv.Atoi(tags[AutoExtendHighWatermarkTag])
+	if err != nil {
+		return fmt.Errorf("invalid auto extend high watermark of filesystem: %s. Error: %v", filesystemID, err)
+	}
+
+	filesystemResp, err := f.FindFilesystemByID(ctx, filesystemID)
+	if err != nil {
+		return err
+	}
+	sizeTotal, sizeUsed := filesystemResp.FileContent.SizeTotal, filesystemResp.FileContent.SizeUsed
+	if sizeUsed*100 < sizeTotal*uint64(highWatermark) {
+		return nil
+	}
+	newSize := 2 * sizeTotal
+	if newSize > maxSize {
+		newSize = maxSize
+	}
+	if newSize <= sizeTotal {
+		return fmt.Errorf("filesystem: %s used space %d reached the high watermark but it's size %d reached the auto extend max size", filesystemID, sizeUsed, sizeTotal)
+	}
+	log.Infof("Auto extending filesystem: %s from %d to %d, used space: %d", filesystemID, sizeTotal, newSize, sizeUsed)
+	return f.ExpandFilesystem(ctx, filesystemID, newSize)
+}
+
+//GetFilesystemTieringPolicy - Returns the effective FAST VP tiering policy of the filesystem
+func (f *filesystem) GetFilesystemTieringPolicy(ctx context.Context, filesystemID string) (int, error) {
+	filesystem, err := f.FindFilesystemByID(ctx, filesystemID)
+	if err != nil {
+		return 0, err
+	}
+	return int(filesystem.FileContent.TieringPolicy), nil
+}
+
+//BulkSetTieringPolicy - Set the FAST VP tiering policy of the filesystems (Ex: after a FAST VP policy change), sending
+//at most BulkModifyMaxParallel modify requests concurrently. The outcome of each filesystem is returned in the order of
+//filesystemIDs, along with an error summarizing the failures if any. An invalid tiering policy fails before any modify.
+//Once the context is done no more modify is started, the filesystems not attempted get the context error.
+func (f *filesystem) BulkSetTieringPolicy(ctx context.Context, filesystemIDs []string, tieringPolicy int) ([]types.ModifyResult, error) {
+	if tieringPolicy < TieringPolicyAutotierHigh || tieringPolicy > TieringPolicyNoDataMovement {
+		return nil, fmt.Errorf("invalid tiering policy: %d, it should be in between %d-%d", tieringPolicy, TieringPolicyAutotierHigh, TieringPolicyNoDataMovement)
+	}
+
+	results := make([]types.ModifyResult, len(filesystemIDs))
+	slots := make(chan struct{}, BulkModifyMaxParallel)
+	var wg sync.WaitGroup
+	for i, filesystemID := range filesystemIDs {
+		//The filesystems not attempted once the context is done get the context error
+		if ctx.Err() != nil {
+			results[i] = types.ModifyResult{ID: filesystemID, Err: fmt.Errorf("filesystem: %s not attempted. Error: %w", filesystemID, ctx.Err())}
+			continue
+		}
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			results[i] = types.ModifyResult{ID: filesystemID, Err: fmt.Errorf("filesystem: %s not attempted. Error: %w", filesystemID, ctx.Err())}
+			continue
+		}
+		wg.Add(1)
+		go func(i int, filesystemID string) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			results[i] = types.ModifyResult{ID: filesystemID, Err: f.setFilesystemTieringPolicy(ctx, filesystemID, tieringPolicy)}
+		}(i, filesystemID)
+	}
+	wg.Wait()
+
+	var failures []string
+	for _, result := range results {
+		if result.Err != nil {
+			failures = append(failures, result.Err.Error())
+		}
+	}
+	if len(failures) > 0 {
+		return results, fmt.Errorf("unable to set tiering policy of %d of %d filesystems: %s", len(failures), len(filesystemIDs), strings.Join(failures, "; "))
+	}
+	return results, nil
+}
+
+//setFilesystemTieringPolicy sends the modify of the FAST VP tiering policy of the filesystem
+func (f *filesystem) setFilesystemTieringPolicy(ctx context.Context, filesystemID string, tieringPolicy int) error {
+	if len(filesystemID) == 0 {
+		return errors.New("Filesystem Id cannot be empty")
+	}
+	filesystemResp, err := f.FindFilesystemByID(ctx, filesystemID, "id", "storageResource")
+	if err != nil {
+		return fmt.Errorf("filesystem: %s. Error: %v", filesystemID, err)
+	}
+	filesystemModifyParam := types.FsModifyParameters{
+		FsParameters: &types.FsModifyFsParameters{
+			FastVPParameters: &types.FastVPParameters{TieringPolicy: tieringPolicy},
+		},
+	}
+	err = f.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityModifyFilesystemURI, filesystemResp.FileContent.StorageResource.ID), filesystemModifyParam, nil)
+	if err != nil {
+		return fmt.Errorf("set filesystem: %s tiering policy to %d failed. Error: %v", filesystemID, tieringPolicy, err)
+	}
+	return nil
+}
+
+//GetFilesystemSnapshotStats - Returns the number of snapshots of the filesystem and the space allocated to them in
+//bytes (snapsSizeAllocated of the filesystem), i.e. the pool space used by the snapshots and freed by deleting them all
+func (f *filesystem) GetFilesystemSnapshotStats(ctx context.Context, filesystemID string) (int, uint64, error) {
+	filesystem, err := f.FindFilesystemByID(ctx, filesystemID, "id", "snapCount", "snapsSizeAllocated")
+	if err != nil {
+		return 0, 0, err
+	}
+	return filesystem.FileContent.SnapCount, filesystem.FileContent.SnapsSizeAllocated, nil
+}
+
+//GetFilesystemDataReductionStats - Returns the space saved by the data reduction of the filesystem in bytes, as a
+//percentage and as a ratio, read from it's storage resource. The savings are 0 when data reduction is disabled.
+func (f *filesystem) GetFilesystemDataReductionStats(ctx context.Context, filesystemID string) (*types.DataReductionStats, error) {
+	filesystem, err := f.FindFilesystemByID(ctx, filesystemID, "id", "storageResource", "isDataReductionEnabled")
+	if err != nil {
+		return nil, err
+	}
+	resourceID := filesystem.FileContent.StorageResource.ID
+	storageResourceResp := &types.StorageResourceParameters{}
+	err = f.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceWithFieldsURI, api.StorageResourceAction, resourceID, StorageResourceDataReductionFields), nil, storageResourceResp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get data reduction stats of filesystem: %s. Error: %v", filesystemID, err)
+	}
+	content := storageResourceResp.StorageResourceContent
+	return &types.DataReductionStats{
+		IsDataReductionEnabled: filesystem.FileContent.IsDataReductionEnabled,
+		SizeSaved:              content.DataReductionSizeSaved,
+		Percent:                content.DataReductionPercent,
+		Ratio:                  content.DataReductionRatio,
+	}, nil
+}
@@ -2,9 +2,17 @@ package gounity
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/dell/gounity/api"
+	"github.com/dell/gounity/types"
 )
 
 var fsName string
@@ -18,7 +26,202 @@ const (
 	NFSShareNamePrefix = "csishare-"
 )
 
+func TestFilesystemOffline(t *testing.T) {
+	ctx = context.Background()
+
+	deleteFilesystemWithSnapshotsTest(t)
+	copyNFSShareConfigConflictTest(t)
+	modifyMarkedFilesystemDescriptionTest(t)
+	filesystemSnapshotStatsTest(t)
+	rehomeNFSShareToSameNASServerTest(t)
+	listNFSSharesForHostPagesTest(t)
+}
+
+func deleteFilesystemWithSnapshotsTest(t *testing.T) {
+	fmt.Println("Begin - Delete Filesystem With Snapshots Test")
+
+	var description string
+	client, server := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(api.HeaderKeyContentType, api.HeaderValContentTypeJSON)
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"content":{"id":"fs_1","name":"fs-1","storageResource":{"id":"res_1"}}}`)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprintf(w, `{"error":{"errorCode":100666391,"httpStatusCode":422,"messages":[{"en-US":"The filesystem has snapshots. (Error Code:%s)"}]}}`, AttachedSnapshotsErrorCode)
+		case http.MethodPost:
+			modify := types.FsModifyParameters{}
+			_ = json.NewDecoder(r.Body).Decode(&modify)
+			description = modify.Description
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	defer server.Close()
+
+	start := time.Now()
+	err := NewFilesystem(client).DeleteFilesystemAndWait(ctx, "fs_1", time.Second)
+	if !errors.Is(err, ErrFilesystemMarkedForDeletion) || time.Since(start) > 5*time.Second {
+		t.Fatalf("Delete and wait of a filesystem having snapshots did not return ErrFilesystemMarkedForDeletion: %v", err)
+	}
+	if !strings.Contains(description, MarkFilesystemForDeletion) {
+		t.Fatalf("Filesystem having snapshots not marked for deletion, description: %s", description)
+	}
+
+	fmt.Println("Delete Filesystem With Snapshots Test Successful")
+}
+
+func listNFSSharesForHostPagesTest(t *testing.T) {
+	fmt.Println("Begin - List NFS Shares For Host Pages Test")
+
+	pages := map[string]string{
+		"1": `{"entryCount":3,"links":[{"rel":"next","href":"&page=2"}],"entries":[
+			{"content":{"id":"NFSShare_1","readWriteHosts":[{"id":"Host_1"}]}},
+			{"content":{"id":"NFSShare_2","readOnlyHosts":[{"id":"Host_2"}]}}]}`,
+		"2": `{"entryCount":3,"entries":[{"content":{"id":"NFSShare_3","rootAccessHosts":[{"id":"Host_1"}]}}]}`,
+	}
+	client, server := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(api.HeaderKeyContentType, api.HeaderValContentTypeJSON)
+		if r.URL.Query().Get("per_page") != "2" {
+			t.Errorf("Unexpected page size: %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, pages[r.URL.Query().Get("page")])
+	})
+	defer server.Close()
+	fileAPI := NewFilesystem(client)
+
+	nfsShares, token, err := fileAPI.ListNFSSharesForHost(ctx, "Host_1", "", 2)
+	if err != nil || token == "" || len(nfsShares) != 1 || nfsShares[0].NFSShareContent.ID != "NFSShare_1" {
+		t.Fatalf("List first page of NFS Shares for host failed: %+v %s %v", nfsShares, token, err)
+	}
+	nfsShares, token, err = fileAPI.ListNFSSharesForHost(ctx, "Host_1", token, 0)
+	if err != nil || token != "" || len(nfsShares) != 1 || nfsShares[0].NFSShareContent.ID != "NFSShare_3" {
+		t.Fatalf("List last page of NFS Shares for host failed: %+v %s %v", nfsShares, token, err)
+	}
+
+	//Negative case
+	_, _, err = fileAPI.ListNFSSharesForHost(ctx, "Host_1", "dummy-token", 2)
+	if !errors.Is(err, ErrInvalidPageToken) {
+		t.Fatalf("List NFS Shares for host with invalid token case - failed: %v", err)
+	}
+
+	fmt.Println("List NFS Shares For Host Pages Test Successful")
+}
+
+func rehomeNFSShareToSameNASServerTest(t *testing.T) {
+	fmt.Println("Begin - Rehome NFS Share To Same NAS Server Test")
+
+	client, server := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(api.HeaderKeyContentType, api.HeaderValContentTypeJSON)
+		switch {
+		case r.Method != http.MethodGet:
+			t.Errorf("NFS Share modified by a rehome to the same NAS server: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == fmt.Sprintf(api.UnityAPIGetResourceURI, api.NfsShareAction, "NFSShare_1"):
+			fmt.Fprint(w, `{"content":{"id":"NFSShare_1","name":"share-1","path":"/","filesystem":{"id":"fs_1"}}}`)
+		case r.URL.Path == fmt.Sprintf(api.UnityAPIGetResourceURI, api.FileSystemAction, "fs_1"):
+			fmt.Fprint(w, `{"content":{"id":"fs_1","nasServer":{"id":"nas_1"}}}`)
+		case r.URL.Path == fmt.Sprintf(api.UnityAPIGetResourceURI, api.FileSystemAction, "fs_2"):
+			fmt.Fprint(w, `{"content":{"id":"fs_2","nasServer":{"id":"nas_1"}}}`)
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	_, err := NewFilesystem(client).RehomeNFSShare(ctx, "NFSShare_1", "fs_2")
+	if !errors.Is(err, ErrRehomeToSameNASServer) {
+		t.Fatalf("Rehome NFS Share to a filesystem of the same NAS server did not return ErrRehomeToSameNASServer: %v", err)
+	}
+
+	fmt.Println("Rehome NFS Share To Same NAS Server Test Successful")
+}
+
+func filesystemSnapshotStatsTest(t *testing.T) {
+	fmt.Println("Begin - Filesystem Snapshot Stats Test")
+
+	client, server := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(api.HeaderKeyContentType, api.HeaderValContentTypeJSON)
+		if !strings.Contains(r.URL.Query().Get("fields"), "snapsSizeAllocated") {
+			t.Errorf("Snapshot space not requested: %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"content":{"id":"fs_1","snapCount":2,"snapsSizeAllocated":1048576}}`)
+	})
+	defer server.Close()
+
+	snapCount, snapSpace, err := NewFilesystem(client).GetFilesystemSnapshotStats(ctx, "fs_1")
+	if err != nil || snapCount != 2 || snapSpace != 1048576 {
+		t.Fatalf("Get filesystem snapshot stats failed: %d %d %v", snapCount, snapSpace, err)
+	}
+
+	fmt.Println("Filesystem Snapshot Stats Test Successful")
+}
+
+func modifyMarkedFilesystemDescriptionTest(t *testing.T) {
+	fmt.Println("Begin - Modify Marked Filesystem Description Test")
+
+	client, server := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(api.HeaderKeyContentType, api.HeaderValContentTypeJSON)
+		if r.Method != http.MethodGet {
+			t.Errorf("Description of a filesystem marked for deletion modified: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		description, _ := json.Marshal(joinFilesystemDescription(MarkFilesystemForDeletion, `{"team":"storage"}`))
+		fmt.Fprintf(w, `{"content":{"id":"fs_1","name":"fs-1","description":%s,"storageResource":{"id":"res_1"}}}`, description)
+	})
+	defer server.Close()
+
+	err := NewFilesystem(client).ModifyFilesystemDescription(ctx, "fs_1", "new description")
+	if !errors.Is(err, ErrFilesystemMarkedForDeletion) {
+		t.Fatalf("Modify description of a filesystem marked for deletion did not return ErrFilesystemMarkedForDeletion: %v", err)
+	}
+
+	fmt.Println("Modify Marked Filesystem Description Test Successful")
+}
+
+func copyNFSShareConfigConflictTest(t *testing.T) {
+	fmt.Println("Begin - Copy NFS Share Config Conflict Test")
+
+	dstReadWriteHost := "Host_2"
+	client, server := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(api.HeaderKeyContentType, api.HeaderValContentTypeJSON)
+		switch r.URL.Path {
+		case fmt.Sprintf(api.UnityAPIGetResourceURI, api.NfsShareAction, "NFSShare_1"):
+			fmt.Fprint(w, `{"content":{"id":"NFSShare_1","name":"share-1","path":"/","defaultAccess":0,"filesystem":{"id":"fs_1"},"readWriteHosts":[{"id":"Host_1"}]}}`)
+		case fmt.Sprintf(api.UnityAPIInstanceTypeResources, api.HostAction):
+			fmt.Fprint(w, `{"entries":[{"content":{"id":"Host_1"}},{"content":{"id":"Host_2"}}]}`)
+		case fmt.Sprintf(api.UnityAPIGetResourceURI, api.FileSystemAction, "fs_2"):
+			fmt.Fprint(w, `{"content":{"id":"fs_2","name":"fs-2","storageResource":{"id":"res_2"},"nasServer":{"id":"nas_2"}}}`)
+		case fmt.Sprintf(api.UnityAPIInstanceTypeResources, api.NfsShareAction):
+			fmt.Fprintf(w, `{"entries":[{"content":{"id":"NFSShare_2","name":"share-1","path":"/","defaultAccess":0,"filesystem":{"id":"fs_2"},"readWriteHosts":[{"id":"%s"}]}}]}`, dstReadWriteHost)
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+	fileAPI := NewFilesystem(client)
+
+	_, err := fileAPI.CopyNFSShareConfig(ctx, "NFSShare_1", "fs_2")
+	if !errors.Is(err, ErrorNFSShareConflict) {
+		t.Fatalf("Copy NFS share config over a share with different host access did not return ErrorNFSShareConflict: %v", err)
+	}
+
+	dstReadWriteHost = "Host_1"
+	copied, err := fileAPI.CopyNFSShareConfig(ctx, "NFSShare_1", "fs_2")
+	if err != nil {
+		t.Fatalf("Copy NFS share config over a share with the same host access failed: %v", err)
+	}
+	if copied.NFSShareContent.ID != "NFSShare_2" {
+		t.Fatalf("Copy NFS share config returned an unexpected share: %+v", copied.NFSShareContent)
+	}
+
+	fmt.Println("Copy NFS Share Config Conflict Test Successful")
+}
+
 func TestFilesystem(t *testing.T) {
+	requireArray(t)
 
 	now := time.Now()
 	timeStamp := now.Format("20060102150405")
@@ -31,8 +234,15 @@ func TestFilesystem(t *testing.T) {
 	createNfsShareTest(t)
 	findNfsShareTest(t)
 	modifyNfsShareTest(t)
+	removeNfsShareHostAccessTest(t)
+	expandFilesystemWithNfsShareTest(t)
 	deleteNfsShareTest(t)
 	expandFilesystemTest(t)
+	modifyFilesystemEventSettingsTest(t)
+	setFilesystemSnapAutoDeletePolicyTest(t)
+	filesystemTagsTest(t)
+	filesystemAutoExtendTest(t)
+	modifyFilesystemNameTest(t)
 	deleteFilesystemTest(t)
 }
 
@@ -62,6 +272,22 @@ func findNasServerTest(t *testing.T) {
 		t.Fatal("Find NAS server using empty ID - Negative case failed")
 	}
 
+	//DNS & NIS of the test NAS server are left as configured, only invalid requests are sent
+	err = testConf.fileAPI.SetNASServerDNS(ctx, testConf.nasServer, "example.com", []string{"dummy-address"})
+	if err == nil {
+		t.Fatal("Set NAS server DNS with invalid address - Negative case failed")
+	}
+
+	err = testConf.fileAPI.SetNASServerNIS(ctx, testConf.nasServer, "", []string{"10.0.0.1"})
+	if err == nil {
+		t.Fatal("Set NAS server NIS with empty domain - Negative case failed")
+	}
+
+	err = testConf.fileAPI.SetNASServerDNS(ctx, "nas_dummy_1", "example.com", []string{"10.0.0.1"})
+	if err == nil {
+		t.Fatal("Set NAS server DNS with invalid ID - Negative case failed")
+	}
+
 	fmt.Println("Find Nas Server Test Successful")
 }
 
@@ -69,11 +295,26 @@ func createFilesystemTest(t *testing.T) {
 
 	fmt.Println("Begin - Create Filesystem Test")
 
-	_, err := testConf.fileAPI.CreateFilesystem(ctx, fsName, testConf.poolID, "Unit test resource", testConf.nasServer, 5368709120, 0, 8192, 0, true, false)
+	filesystem, err := testConf.fileAPI.CreateFilesystem(ctx, fsName, testConf.poolID, "Unit test resource", testConf.nasServer, 5368709120, 0, 8192, 0, true, false)
 	if err != nil {
 		t.Fatalf("Create filesystem failed: %v", err)
 	}
 
+	storageResource, err := testConf.client.FindStorageResourceByID(ctx, filesystem.FileContent.StorageResource.ID)
+	if err != nil || storageResource.StorageResourceContent.Type != StorageResourceTypeFilesystem {
+		t.Fatalf("Find storage resource by Id failed: %v", err)
+	}
+	_, err = testConf.client.FindStorageResourceByID(ctx, "dummy_res_1")
+	if err != ErrorStorageResourceNotFound {
+		t.Fatalf("Find storage resource with invalid Id - Negative case failed: %v", err)
+	}
+
+	identity, err := testConf.fileAPI.GetFilesystemIdentity(ctx, filesystem.FileContent.StorageResource.ID)
+	fmt.Println("Filesystem identity:", prettyPrintJSON(identity), err)
+	if err != nil {
+		t.Fatalf("Get filesystem identity failed: %v", err)
+	}
+
 	//Negative cases
 
 	fsNameTemp := ""
@@ -88,12 +329,39 @@ func createFilesystemTest(t *testing.T) {
 		t.Fatal("Create filesystem with fs name more than 63 characters - Negative case failed")
 	}
 
+	for _, validName := range []string{"dummy-fs", "dummy fs+data#1", ".dummy-fs"} {
+		if err = ValidateFilesystemName(validName); err != nil {
+			t.Fatalf("Validate filesystem name %s failed: %v", validName, err)
+		}
+	}
+	for _, invalidName := range []string{" dummy-fs", "dummy-fs ", "dummy\tfs", "dummy\nfs"} {
+		if err = ValidateFilesystemName(invalidName); err == nil {
+			t.Fatalf("Validate filesystem name %q - Negative case failed", invalidName)
+		}
+	}
+
 	poolIDTemp := "dummy_pool_1"
 	_, err = testConf.fileAPI.CreateFilesystem(ctx, fsName, poolIDTemp, "Unit test resource", testConf.nasServer, 5368709120, 0, 8192, 0, true, false)
 	if err == nil {
 		t.Fatal("Create filesystem with invalid storage pool - Negative case failed")
 	}
 
+	_, err = testConf.fileAPI.CreateFilesystem(ctx, fsName, testConf.poolID, "Unit test resource", "", 0, 0, 8192, 0, true, false)
+	if err == nil {
+		t.Fatal("Create filesystem with zero size and empty NAS server - Negative case failed")
+	}
+
+	_, err = testConf.fileAPI.CreateFilesystem(ctx, "", testConf.poolID, "Unit test resource", "", 0, 0, 4096, 0, true, false)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.Problems) != 4 {
+		t.Fatalf("Create filesystem with several invalid fields did not report all the problems: %v", err)
+	}
+
+	_, err = testConf.fileAPI.CreateFilesystem(ctx, fsName, testConf.poolID, "Unit test resource", testConf.nasServer, 5368709120, 0, 4096, 0, true, false)
+	if err == nil || !strings.Contains(err.Error(), "host IO size 4096") {
+		t.Fatalf("Create filesystem with unsupported host IO size - Negative case failed: %v", err)
+	}
+
 	fmt.Println("Create Filesystem test successful")
 
 }
@@ -115,6 +383,142 @@ func findFilesystemTest(t *testing.T) {
 	fsID = filesystem.FileContent.ID
 	nfsShareName = NFSShareNamePrefix + filesystem.FileContent.Name
 
+	exists, err := testConf.fileAPI.FilesystemExists(ctx, fsName)
+	if err != nil || !exists {
+		t.Fatalf("Filesystem exists by name failed: %v", err)
+	}
+	exists, err = testConf.fileAPI.FilesystemExists(ctx, "dummy-fs-1")
+	if err != nil || exists {
+		t.Fatalf("Filesystem exists with invalid name case failed: %v", err)
+	}
+
+	found := false
+	pages := 0
+	for token := ""; ; {
+		var filesystems []types.Filesystem
+		filesystems, token, err = testConf.fileAPI.ListFilesystems(ctx, token, 2)
+		if err != nil || len(filesystems) > 2 {
+			t.Fatalf("List filesystems page failed: %d %v", len(filesystems), err)
+		}
+		pages++
+		for _, listed := range filesystems {
+			found = found || listed.FileContent.ID == fsID
+		}
+		if token == "" {
+			break
+		}
+	}
+	if !found {
+		t.Fatalf("List filesystems across %d pages did not return filesystem: %s", pages, fsID)
+	}
+	_, _, err = testConf.fileAPI.ListFilesystems(ctx, "dummy-token", 2)
+	if !errors.Is(err, ErrInvalidPageToken) {
+		t.Fatalf("List filesystems with invalid page token case failed: %v", err)
+	}
+
+	tieringPolicy, err := testConf.fileAPI.GetFilesystemTieringPolicy(ctx, fsID)
+	if err != nil {
+		t.Fatalf("Get filesystem tiering policy failed: %v", err)
+	}
+	fmt.Println("Filesystem tiering policy:", tieringPolicy)
+
+	results, err := testConf.fileAPI.BulkSetTieringPolicy(ctx, []string{"dummy-fs-1"}, TieringPolicyAutotier)
+	if err == nil || len(results) != 1 || results[0].Err == nil {
+		t.Fatalf("Bulk set tiering policy of an invalid filesystem - Negative case failed: %+v %v", results, err)
+	}
+	results, err = testConf.fileAPI.BulkSetTieringPolicy(ctx, []string{fsID}, TieringPolicyMixed)
+	if err == nil || results != nil {
+		t.Fatalf("Bulk set invalid tiering policy - Negative case failed: %v", err)
+	}
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	results, err = testConf.fileAPI.BulkSetTieringPolicy(canceledCtx, []string{fsID, fsID}, TieringPolicyAutotier)
+	if err == nil || len(results) != 2 || !errors.Is(results[0].Err, context.Canceled) || !errors.Is(results[1].Err, context.Canceled) {
+		t.Fatalf("Bulk set tiering policy with a canceled context - Negative case failed: %+v %v", results, err)
+	}
+
+	snapCount, snapSpace, err := testConf.fileAPI.GetFilesystemSnapshotStats(ctx, fsID)
+	if err != nil || snapCount != 0 || snapSpace != 0 {
+		t.Fatalf("Get filesystem snapshot stats of a new filesystem failed: %d %d %v", snapCount, snapSpace, err)
+	}
+
+	drStats, err := testConf.fileAPI.GetFilesystemDataReductionStats(ctx, fsID)
+	if err != nil {
+		t.Fatalf("Get filesystem data reduction stats failed: %v", err)
+	}
+	fmt.Println("Filesystem data reduction stats:", prettyPrintJSON(drStats))
+	_, err = testConf.fileAPI.GetFilesystemDataReductionStats(ctx, "dummy-fs-1")
+	if err == nil {
+		t.Fatal("Get data reduction stats of an invalid filesystem - Negative case failed")
+	}
+
+	_, err = testConf.fileAPI.CreateNFSShareFromLatestSnapshot(ctx, nfsShareName+"-latest", "/", fsID, ReadOnlyDefaultAccess)
+	if err == nil {
+		t.Fatalf("Create NFS Share from latest snapshot of a filesystem without snapshots case - failed: %v", err)
+	}
+
+	//The NAS server is left untouched as it's filesystems are not deleted
+	err = testConf.fileAPI.DeleteNASServer(ctx, testConf.nasServer, true, false)
+	if !errors.Is(err, ErrNASServerDeletionBlocked) || !strings.Contains(err.Error(), fsID) {
+		t.Fatalf("Delete NAS server having filesystems case - failed: %v", err)
+	}
+	err = testConf.fileAPI.DeleteNASServer(ctx, "", true, true)
+	if err == nil {
+		t.Fatal("Delete NAS server using empty ID - Negative case failed")
+	}
+
+	mountTarget, err := testConf.fileAPI.GetFilesystemMountTarget(ctx, fsID)
+	if err != nil || net.ParseIP(mountTarget) == nil {
+		t.Fatalf("Get filesystem mount target failed: %s %v", mountTarget, err)
+	}
+	_, err = testConf.fileAPI.GetFilesystemMountTarget(ctx, "dummy-fs-1")
+	if err == nil {
+		t.Fatal("Get mount target of an invalid filesystem - Negative case failed")
+	}
+
+	currentSP, err := testConf.fileAPI.GetFilesystemCurrentSP(ctx, fsID)
+	if err != nil || (currentSP != SPA && currentSP != SPB) {
+		t.Fatalf("Get filesystem current SP failed: %s %v", currentSP, err)
+	}
+	otherSP := SPA
+	if currentSP == SPA {
+		otherSP = SPB
+	}
+	_, err = testConf.fileAPI.CreateFilesystemOnSP(ctx, fsName+"-sp", testConf.poolID, "Unit test resource", testConf.nasServer, 5368709120, 0, 8192, 0, true, false, otherSP)
+	if !errors.Is(err, ErrSPMismatch) {
+		t.Fatalf("Create filesystem on the other SP of the NAS server case - failed: %v", err)
+	}
+
+	filesystem, err = testConf.fileAPI.ResolveFilesystem(ctx, fsID)
+	if err != nil || filesystem.FileContent.ID != fsID {
+		t.Fatalf("Resolve filesystem by Id failed: %v", err)
+	}
+
+	filesystem, err = testConf.fileAPI.ResolveFilesystem(ctx, fsName)
+	if err != nil || filesystem.FileContent.ID != fsID {
+		t.Fatalf("Resolve filesystem by name failed: %v", err)
+	}
+
+	filesystems := &types.ListFilesystems{}
+	token, err := testConf.client.ListResourcesByName(ctx, api.FileSystemAction, fsName, FileSystemDisplayFields, "", 0, filesystems)
+	if err != nil || token != "" {
+		t.Fatalf("List filesystems by name failed: %s %v", token, err)
+	}
+	if len(filesystems.Filesystems) != 1 {
+		t.Fatalf("List filesystems by name returned %d filesystems, expected 1", len(filesystems.Filesystems))
+	}
+
+	filesystems = &types.ListFilesystems{}
+	err = testConf.client.QueryInstances(ctx, api.FileSystemAction, []string{"id", "name", "pool"}, fmt.Sprintf("pool.id eq \"%s\"", testConf.poolID), filesystems)
+	if err != nil {
+		t.Fatalf("Query filesystems of pool failed: %v", err)
+	}
+	for _, fs := range filesystems.Filesystems {
+		if fs.FileContent.Pool.ID != testConf.poolID {
+			t.Fatalf("Query filesystems of pool %s returned filesystem %s of pool %s", testConf.poolID, fs.FileContent.ID, fs.FileContent.Pool.ID)
+		}
+	}
+
 	fmt.Println("Filesystem ID: " + fsID)
 
 	//Test case :  GET using invalid fsName/ID
@@ -130,6 +534,11 @@ func findFilesystemTest(t *testing.T) {
 		t.Fatal("Find filesystem by Id - Negative case failed")
 	}
 
+	filesystem, err = testConf.fileAPI.ResolveFilesystem(ctx, fsNameTemp)
+	if err == nil {
+		t.Fatal("Resolve filesystem - Negative case failed")
+	}
+
 	//Test case :  GET using empty fsName/ID
 	fsNameTemp = ""
 
@@ -155,6 +564,16 @@ func createNfsShareTest(t *testing.T) {
 		t.Fatalf("Create NFS Share failed: %v", err)
 	}
 
+	_, err = testConf.fileAPI.CreateNFSShare(ctx, nfsShareName, NFSShareLocalPath, fsID, NoneDefaultAccess)
+	if err != nil {
+		t.Fatalf("Create existing NFS Share failed: %v", err)
+	}
+
+	_, err = testConf.fileAPI.CreateNFSShare(ctx, nfsShareName, NFSShareLocalPath, fsID, ReadOnlyDefaultAccess)
+	if !errors.Is(err, ErrorNFSShareConflict) {
+		t.Fatalf("Create existing NFS Share with different default access - Negative case failed: %v", err)
+	}
+
 	//Test case :  Create using invalid fsID
 	fsIDTemp := "dummy-fs-1"
 	_, err = testConf.fileAPI.CreateNFSShare(ctx, nfsShareName, NFSShareLocalPath, fsIDTemp, NoneDefaultAccess)
@@ -174,6 +593,37 @@ func createNfsShareTest(t *testing.T) {
 		t.Fatalf("Create NFS Share with empty share name - Negative case failed")
 	}
 
+	//The path is normalized to /relative-path, which differs from the path of the existing share
+	_, err = testConf.fileAPI.CreateNFSShare(ctx, nfsShareName, "relative-path", fsID, NoneDefaultAccess)
+	if !errors.Is(err, ErrorNFSShareConflict) {
+		t.Fatalf("Create existing NFS Share with another relative path - Negative case failed: %v", err)
+	}
+
+	//The share is created only if the test NAS server has Kerberos configured, and deleted right after
+	_, err = testConf.fileAPI.CreateNFSShareWithKerberos(ctx, nfsShareName+"-krb", NFSShareLocalPath, fsID, NoneDefaultAccess, KerberosSecurity, "")
+	if err == nil {
+		krbShare, err := testConf.fileAPI.FindNFSShareByNameAndFilesystem(ctx, nfsShareName+"-krb", fsID)
+		if err != nil || krbShare.NFSShareContent.MinSecurity != int(KerberosSecurity) {
+			t.Fatalf("Find NFS Share with Kerberos failed: %v", err)
+		}
+		if err = testConf.fileAPI.DeleteNFSShare(ctx, fsID, krbShare.NFSShareContent.ID); err != nil {
+			t.Fatalf("Delete NFS Share with Kerberos failed: %v", err)
+		}
+	} else if !errors.Is(err, ErrKerberosNotConfigured) {
+		t.Fatalf("Create NFS Share with Kerberos failed: %v", err)
+	}
+
+	_, err = testConf.fileAPI.CreateNFSShareWithKerberos(ctx, nfsShareName+"-krb", NFSShareLocalPath, fsID, NoneDefaultAccess, SysSecurity, "")
+	if err == nil {
+		t.Fatalf("Create NFS Share with Kerberos without Kerberos security - Negative case failed")
+	}
+
+	//The existing share uses AUTH_SYS, it must not be accepted as the Kerberos share
+	_, err = testConf.fileAPI.CreateNFSShareWithKerberos(ctx, nfsShareName, NFSShareLocalPath, fsID, NoneDefaultAccess, KerberosSecurity, "")
+	if !errors.Is(err, ErrorNFSShareConflict) && !errors.Is(err, ErrKerberosNotConfigured) {
+		t.Fatalf("Create existing AUTH_SYS NFS Share with Kerberos - Negative case failed: %v", err)
+	}
+
 	fmt.Println("Create NFS Share Test Successful")
 
 }
@@ -189,6 +639,56 @@ func findNfsShareTest(t *testing.T) {
 
 	nfsShareID = nfsShare.NFSShareContent.ID
 
+	exists, err := testConf.fileAPI.NFSShareExists(ctx, nfsShareID)
+	if err != nil || !exists {
+		t.Fatalf("NFS Share exists by Id failed: %v", err)
+	}
+
+	//Copying the configuration to the filesystem of the share finds the share itself
+	copiedNFSShare, err := testConf.fileAPI.CopyNFSShareConfig(ctx, nfsShareID, fsID)
+	if err != nil || copiedNFSShare.NFSShareContent.ID != nfsShareID {
+		t.Fatalf("Copy NFS Share config to the same filesystem failed: %v", err)
+	}
+
+	_, err = testConf.fileAPI.CopyNFSShareConfig(ctx, nfsShareID, "dummy-fs-1")
+	if err == nil {
+		t.Fatal("Copy NFS Share config to an invalid filesystem - Negative case failed")
+	}
+
+	_, err = testConf.fileAPI.RehomeNFSShare(ctx, nfsShareID, fsID)
+	if err == nil {
+		t.Fatal("Rehome NFS Share to it's own filesystem - Negative case failed")
+	}
+
+	_, err = testConf.fileAPI.RehomeNFSShare(ctx, nfsShareID, "dummy-fs-1")
+	if err == nil {
+		t.Fatal("Rehome NFS Share to an invalid filesystem - Negative case failed")
+	}
+	exists, err = testConf.fileAPI.NFSShareExists(ctx, nfsShareID)
+	if err != nil || !exists {
+		t.Fatalf("NFS Share not kept after a failed rehome: %v", err)
+	}
+
+	nfsShare, err = testConf.fileAPI.FindNFSShareByNameAndFilesystem(ctx, nfsShareName, fsID)
+	if err != nil || nfsShare.NFSShareContent.ID != nfsShareID {
+		t.Fatalf("Find NFS Share by name and filesystem failed: %v", err)
+	}
+
+	_, err = testConf.fileAPI.FindNFSShareByNameAndFilesystem(ctx, "dummy-share-1", fsID)
+	if err != ErrorNFSShareNotFound {
+		t.Fatalf("Find NFS Share by name and filesystem with invalid name - Negative case failed: %v", err)
+	}
+
+	nfsShare, err = testConf.fileAPI.FindNFSShareByPath(ctx, fsID, "//")
+	if err != nil || nfsShare.NFSShareContent.ID != nfsShareID {
+		t.Fatalf("Find NFS Share by path failed: %v", err)
+	}
+
+	_, err = testConf.fileAPI.FindNFSShareByPath(ctx, fsID, "dummy-path/")
+	if err != ErrorNFSShareNotFound {
+		t.Fatalf("Find NFS Share by path with invalid path - Negative case failed: %v", err)
+	}
+
 	_, err = testConf.fileAPI.FindNFSShareByID(ctx, nfsShareID)
 	if err != nil {
 		t.Fatalf("Find NFS Share by ID failed: %v", err)
@@ -256,6 +756,35 @@ func modifyNfsShareTest(t *testing.T) {
 		t.Fatalf("Modify NFS Share by name failed: %v", err)
 	}
 
+	err = testConf.fileAPI.ModifyNFSShareHostAccessWithMode(ctx, fsID, nfsShareID, hostIDList, ReadOnlyAccessType, AppendHostAccessMode)
+	if err != nil {
+		t.Fatalf("Modify NFS Share with append mode failed: %v", err)
+	}
+
+	clusterHosts := []types.HostSpec{{Name: testConf.nodeHostName, IPAddresses: []string{testConf.nodeHostIP}}}
+	err = testConf.fileAPI.GrantClusterAccess(ctx, fsID, nfsShareID, clusterHosts, ReadOnlyAccessType)
+	if err != nil {
+		t.Fatalf("Grant cluster access to an existing host failed: %v", err)
+	}
+
+	clusterHosts = []types.HostSpec{{Name: testConf.nodeHostName, IPAddresses: []string{"dummy-ip"}}}
+	err = testConf.fileAPI.GrantClusterAccess(ctx, fsID, nfsShareID, clusterHosts, ReadOnlyAccessType)
+	if err == nil {
+		t.Fatalf("Grant cluster access with invalid IP address - Negative case Failed")
+	}
+
+	testConf.fileAPI.SetSkipVerificationRead(true)
+	err = testConf.fileAPI.ModifyNFSShareHostAccess(ctx, fsID, nfsShareID, hostIDList, ReadOnlyAccessType)
+	testConf.fileAPI.SetSkipVerificationRead(false)
+	if err != nil {
+		t.Fatalf("Modify NFS Share without verification read failed: %v", err)
+	}
+
+	err = testConf.fileAPI.ModifyNFSShareHostAccessWithMode(ctx, fsID, nfsShareID, hostIDList, ReadOnlyAccessType, HostAccessMode("dummy-mode"))
+	if err == nil {
+		t.Fatalf("Modify NFS Share with invalid mode - Negative case Failed")
+	}
+
 	fsIDTemp := "dummy-fs-1"
 	err = testConf.fileAPI.ModifyNFSShareHostAccess(ctx, fsIDTemp, nfsShareID, hostIDList, ReadWriteRootAccessType)
 	if err == nil {
@@ -268,10 +797,193 @@ func modifyNfsShareTest(t *testing.T) {
 		t.Fatalf("Modify NFS Share with empty fs ID - Negative case Failed")
 	}
 
+	err = testConf.fileAPI.PromoteSnapshotShareToReadWrite(ctx, nfsShareID)
+	if err == nil {
+		t.Fatalf("Promote NFS Share not created from a snapshot - Negative case Failed")
+	}
+
+	err = testConf.fileAPI.PromoteSnapshotShareToReadWrite(ctx, "")
+	if err == nil {
+		t.Fatalf("Promote NFS Share with empty NFS Share ID - Negative case Failed")
+	}
+
+	err = testConf.fileAPI.ModifySnapshotNFSShareHostAccess(ctx, "dummy_snap_1", nfsShareID, hostIDList, ReadOnlyAccessType)
+	if err == nil {
+		t.Fatalf("Modify snapshot NFS Share host access of a filesystem NFS Share - Negative case Failed")
+	}
+
 	fmt.Println("Modify NFS Share Test Successful")
 
 }
 
+func removeNfsShareHostAccessTest(t *testing.T) {
+
+	fmt.Println("Begin - Remove NFS Share Host Access Test")
+
+	host, err := testConf.hostAPI.FindHostByName(ctx, testConf.nodeHostName)
+	if err != nil {
+		t.Fatalf("Find host failed: %v", err)
+	}
+	hostID := host.HostContent.ID
+
+	containsHost := func(accessType AccessType) bool {
+		nfsShare, err := testConf.fileAPI.FindNFSShareByID(ctx, nfsShareID)
+		if err != nil {
+			t.Fatalf("Find NFS Share by Id failed: %v", err)
+		}
+		for _, id := range getNFSShareHostIDs(nfsShare, accessType) {
+			if id == hostID {
+				return true
+			}
+		}
+		return false
+	}
+
+	accessTypes := []AccessType{ReadOnlyAccessType, ReadWriteAccessType, ReadOnlyRootAccessType, ReadWriteRootAccessType}
+	for _, accessType := range accessTypes {
+		err = testConf.fileAPI.ModifyNFSShareHostAccess(ctx, fsID, nfsShareID, []string{hostID}, accessType)
+		if err != nil {
+			t.Fatalf("Add %s host access failed: %v", accessType, err)
+		}
+		if !containsHost(accessType) {
+			t.Fatalf("Host %s not found in %s access list after add", hostID, accessType)
+		}
+		effectiveAccess, err := testConf.fileAPI.GetEffectiveHostAccess(ctx, nfsShareID, hostID)
+		if err != nil || effectiveAccess != accessType {
+			t.Fatalf("Get effective host access returned %s, expected %s: %v", effectiveAccess, accessType, err)
+		}
+
+		found := false
+		token := ""
+		for {
+			var nfsShares []types.NFSShare
+			nfsShares, token, err = testConf.fileAPI.ListNFSSharesForHost(ctx, hostID, token, 10)
+			if err != nil {
+				t.Fatalf("List NFS Shares for host failed: %v", err)
+			}
+			for _, nfsShare := range nfsShares {
+				found = found || nfsShare.NFSShareContent.ID == nfsShareID
+			}
+			if token == "" {
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("NFS Share %s not listed for host %s with %s access", nfsShareID, hostID, accessType)
+		}
+
+		err = testConf.fileAPI.RemoveNFSShareHostAccess(ctx, fsID, nfsShareID, []string{hostID}, accessType)
+		if err != nil {
+			t.Fatalf("Remove %s host access failed: %v", accessType, err)
+		}
+		if containsHost(accessType) {
+			t.Fatalf("Host %s still present in %s access list after remove", hostID, accessType)
+		}
+	}
+
+	err = testConf.fileAPI.ModifyNFSShareHostAccess(ctx, fsID, nfsShareID, []string{hostID}, NoAccessType)
+	if err != nil || !containsHost(NoAccessType) {
+		t.Fatalf("Add host to the no access list failed: %v", err)
+	}
+	effectiveAccess, err := testConf.fileAPI.GetEffectiveHostAccess(ctx, nfsShareID, hostID)
+	if err != nil || effectiveAccess != NoAccessType {
+		t.Fatalf("Get effective host access of a denied host returned %s: %v", effectiveAccess, err)
+	}
+	err = testConf.fileAPI.RemoveNFSShareHostAccess(ctx, fsID, nfsShareID, []string{hostID}, NoAccessType)
+	if err != nil || containsHost(NoAccessType) {
+		t.Fatalf("Remove host from the no access list failed: %v", err)
+	}
+
+	desired := types.NFSShareAccessSpec{
+		DefaultAccess:  string(NoneDefaultAccess),
+		ReadWriteHosts: []string{hostID},
+	}
+	err = testConf.fileAPI.ReconcileNFSShareAccess(ctx, fsID, nfsShareID, desired)
+	if err != nil {
+		t.Fatalf("Reconcile NFS Share access failed: %v", err)
+	}
+	if !containsHost(ReadWriteAccessType) || containsHost(ReadOnlyAccessType) {
+		t.Fatalf("Reconcile NFS Share access did not apply the desired host lists")
+	}
+
+	desired.ReadWriteHosts = []string{}
+	err = testConf.fileAPI.ReconcileNFSShareAccess(ctx, fsID, nfsShareID, desired)
+	if err != nil {
+		t.Fatalf("Reconcile NFS Share access failed: %v", err)
+	}
+	if containsHost(ReadWriteAccessType) {
+		t.Fatalf("Reconcile NFS Share access did not clear the read-write hosts")
+	}
+
+	desired.RootAccessHosts = []string{hostID}
+	err = testConf.fileAPI.ReconcileNFSShareAccess(ctx, fsID, nfsShareID, desired)
+	if err != nil {
+		t.Fatalf("Reconcile NFS Share access failed: %v", err)
+	}
+	err = testConf.fileAPI.ModifyFilesystemNFSDefaults(ctx, fsID, ReadOnlyDefaultAccess, true)
+	if err != nil {
+		t.Fatalf("Modify filesystem NFS defaults with root squash failed: %v", err)
+	}
+	if !containsHost(ReadWriteAccessType) || containsHost(ReadWriteRootAccessType) {
+		t.Fatalf("Modify filesystem NFS defaults did not squash the root access host")
+	}
+	desired.RootAccessHosts = []string{}
+	err = testConf.fileAPI.ReconcileNFSShareAccess(ctx, fsID, nfsShareID, desired)
+	if err != nil {
+		t.Fatalf("Reconcile NFS Share access failed: %v", err)
+	}
+
+	desired.NoAccessHosts = []string{hostID}
+	err = testConf.fileAPI.ReconcileNFSShareAccess(ctx, fsID, nfsShareID, desired)
+	if err != nil || !containsHost(NoAccessType) {
+		t.Fatalf("Reconcile NFS Share access did not apply the no access hosts: %v", err)
+	}
+	desired.NoAccessHosts = []string{}
+	err = testConf.fileAPI.ReconcileNFSShareAccess(ctx, fsID, nfsShareID, desired)
+	if err != nil || containsHost(NoAccessType) {
+		t.Fatalf("Reconcile NFS Share access did not clear the no access hosts: %v", err)
+	}
+
+	err = testConf.fileAPI.ReconcileNFSShareAccess(ctx, fsID, nfsShareID, types.NFSShareAccessSpec{
+		DefaultAccess:  string(NoneDefaultAccess),
+		ReadWriteHosts: []string{hostID},
+		NoAccessHosts:  []string{hostID},
+	})
+	if err == nil {
+		t.Fatalf("Reconcile NFS Share access with a host allowed and denied - Negative case Failed")
+	}
+
+	err = testConf.fileAPI.ModifyFilesystemNFSDefaults(ctx, fsID, ReadWriteRootDefaultAccess, true)
+	if err == nil {
+		t.Fatalf("Modify filesystem NFS defaults with root default access and root squash - Negative case Failed")
+	}
+
+	//Negative cases
+	err = testConf.fileAPI.ModifyNFSShareHostAccessWithMode(ctx, fsID, nfsShareID, []string{hostID}, AccessType("dummy-access"), ReplaceHostAccessMode)
+	if err == nil {
+		t.Fatalf("Modify NFS Share host access with invalid access type - Negative case Failed")
+	}
+
+	err = testConf.fileAPI.RemoveNFSShareHostAccess(ctx, fsID, nfsShareID, []string{hostID}, AccessType("dummy-access"))
+	if err == nil {
+		t.Fatalf("Remove NFS Share host access with invalid access type - Negative case Failed")
+	}
+
+	err = testConf.fileAPI.RemoveNFSShareHostAccess(ctx, fsID, "", []string{hostID}, ReadOnlyAccessType)
+	if err == nil {
+		t.Fatalf("Remove NFS Share host access with empty NFS Share ID - Negative case Failed")
+	}
+
+	desired.ReadOnlyHosts = []string{hostID}
+	desired.RootAccessHosts = []string{hostID}
+	err = testConf.fileAPI.ReconcileNFSShareAccess(ctx, fsID, nfsShareID, desired)
+	if err == nil {
+		t.Fatalf("Reconcile NFS Share access with host in two access lists - Negative case Failed")
+	}
+
+	fmt.Println("Remove NFS Share Host Access Test Successful")
+}
+
 func deleteNfsShareTest(t *testing.T) {
 
 	fmt.Println("Begin - Delete NFS Share Test")
@@ -281,13 +993,32 @@ func deleteNfsShareTest(t *testing.T) {
 		t.Fatalf("Delete NFS Share failed: %v", err)
 	}
 
+	_, err = testConf.fileAPI.CreateNFSShare(ctx, nfsShareName+"-all", NFSShareLocalPath, fsID, NoneDefaultAccess)
+	if err != nil {
+		t.Fatalf("Create NFS Share failed: %v", err)
+	}
+	err = testConf.fileAPI.DeleteAllNFSShares(ctx, fsID)
+	if err != nil {
+		t.Fatalf("Delete all NFS Shares failed: %v", err)
+	}
+	_, err = testConf.fileAPI.FindNFSShareByNameAndFilesystem(ctx, nfsShareName+"-all", fsID)
+	if err != ErrorNFSShareNotFound {
+		t.Fatalf("NFS Share found after delete all NFS Shares: %v", err)
+	}
+
 	//Test case :  Delete using invalid shareID and fsID
 	nfsShareIDTemp := "dummy-fs-1"
 	fsIDTemp := "dummy-fs-1"
 
+	//Deleting an already deleted or non-existent share is idempotent
+	err = testConf.fileAPI.DeleteNFSShare(ctx, fsID, nfsShareID)
+	if err != nil {
+		t.Fatalf("Delete already deleted NFS Share failed: %v", err)
+	}
+
 	err = testConf.fileAPI.DeleteNFSShare(ctx, fsID, nfsShareIDTemp)
-	if err == nil {
-		t.Fatalf("Delete NFS Share with invalid nfs share ID failed")
+	if err != nil {
+		t.Fatalf("Delete NFS Share with invalid nfs share ID failed: %v", err)
 	}
 
 	err = testConf.fileAPI.DeleteNFSShare(ctx, fsIDTemp, nfsShareIDTemp)
@@ -316,6 +1047,43 @@ func deleteNfsShareTest(t *testing.T) {
 
 }
 
+func expandFilesystemWithNfsShareTest(t *testing.T) {
+
+	fmt.Println("Begin - Expand Filesystem With NFS Share Test")
+
+	before, err := testConf.fileAPI.FindNFSShareByID(ctx, nfsShareID)
+	if err != nil {
+		t.Fatalf("Find NFS Share by Id failed: %v", err)
+	}
+
+	err = testConf.fileAPI.ExpandFilesystem(ctx, fsID, 6442450944)
+	if err != nil {
+		t.Fatalf("Expand filesystem failed: %v", err)
+	}
+
+	after, err := testConf.fileAPI.FindNFSShareByID(ctx, nfsShareID)
+	if err != nil {
+		t.Fatalf("Find NFS Share by Id after expand failed: %v", err)
+	}
+	beforeContent, afterContent := before.NFSShareContent, after.NFSShareContent
+	if afterContent.Path != beforeContent.Path || afterContent.DefaultAccess != beforeContent.DefaultAccess {
+		t.Fatalf("NFS Share changed by expand, before: %s after: %s", prettyPrintJSON(beforeContent), prettyPrintJSON(afterContent))
+	}
+	hostLists := map[string][2][]types.HostContent{
+		"readOnlyHosts":           {beforeContent.ReadOnlyHosts, afterContent.ReadOnlyHosts},
+		"readWriteHosts":          {beforeContent.ReadWriteHosts, afterContent.ReadWriteHosts},
+		"readOnlyRootAccessHosts": {beforeContent.ReadOnlyRootAccessHosts, afterContent.ReadOnlyRootAccessHosts},
+		"rootAccessHosts":         {beforeContent.RootAccessHosts, afterContent.RootAccessHosts},
+	}
+	for listName, hosts := range hostLists {
+		if prettyPrintJSON(hosts[0]) != prettyPrintJSON(hosts[1]) {
+			t.Fatalf("NFS Share %s changed by expand, before: %s after: %s", listName, prettyPrintJSON(hosts[0]), prettyPrintJSON(hosts[1]))
+		}
+	}
+
+	fmt.Println("Expand Filesystem With NFS Share Test Successful")
+}
+
 func expandFilesystemTest(t *testing.T) {
 
 	fmt.Println("Begin - Expand Filesystem Test")
@@ -342,18 +1110,242 @@ func expandFilesystemTest(t *testing.T) {
 		t.Fatalf("Expand filesystem with smaller size case failed: %v", err)
 	}
 
+	//The test filesystem is created thin
+	err = testConf.fileAPI.ModifyFilesystemThinProvisioning(ctx, fsID, true)
+	if err != nil {
+		t.Fatalf("Modify filesystem thin provisioning to current value failed: %v", err)
+	}
+
+	err = testConf.fileAPI.ModifyFilesystemThinProvisioning(ctx, fsID, false)
+	if !errors.Is(err, ErrThinConversionNotSupported) {
+		t.Fatalf("Modify filesystem thin provisioning to thick case failed: %v", err)
+	}
+
 	fmt.Println("Expand Filesystem Test Successful")
 }
 
+func modifyFilesystemEventSettingsTest(t *testing.T) {
+
+	fmt.Println("Begin - Modify Filesystem Event Settings Test")
+
+	fileEventSettings := types.FileEventSettings{
+		IsCIFSEnabled: false,
+		IsNFSEnabled:  false,
+	}
+	err := testConf.fileAPI.ModifyFilesystemEventSettings(ctx, fsID, fileEventSettings)
+	if err != nil {
+		t.Fatalf("Modify filesystem event settings failed: %v", err)
+	}
+
+	//Negative cases
+	fsIDTemp := ""
+	err = testConf.fileAPI.ModifyFilesystemEventSettings(ctx, fsIDTemp, fileEventSettings)
+	if err == nil {
+		t.Fatalf("Modify filesystem event settings with empty Id case failed: %v", err)
+	}
+
+	err = testConf.fileAPI.EnableCIFSOnFilesystem(ctx, fsIDTemp)
+	if err == nil {
+		t.Fatalf("Enable CIFS on filesystem with empty Id case failed: %v", err)
+	}
+
+	fmt.Println("Modify Filesystem Event Settings Test Successful")
+}
+
+func setFilesystemSnapAutoDeletePolicyTest(t *testing.T) {
+
+	fmt.Println("Begin - Set Filesystem Snapshot Auto Delete Policy Test")
+
+	err := testConf.fileAPI.SetFilesystemSnapAutoDeletePolicy(ctx, fsID, SnapAutoDeletePolicyEnabled, SnapAutoDeletePolicyDisabled)
+	if err != nil {
+		t.Fatalf("Set filesystem snapshot auto delete policy failed: %v", err)
+	}
+
+	//Negative cases
+	err = testConf.fileAPI.SetFilesystemSnapAutoDeletePolicy(ctx, "", SnapAutoDeletePolicyEnabled, SnapAutoDeletePolicyEnabled)
+	if err == nil {
+		t.Fatalf("Set filesystem snapshot auto delete policy with empty Id case failed: %v", err)
+	}
+
+	err = testConf.fileAPI.SetFilesystemSnapAutoDeletePolicy(ctx, fsID, 5, SnapAutoDeletePolicyEnabled)
+	if err == nil {
+		t.Fatalf("Set filesystem snapshot auto delete policy with invalid policy case failed: %v", err)
+	}
+
+	fmt.Println("Set Filesystem Snapshot Auto Delete Policy Test Successful")
+}
+
+func filesystemTagsTest(t *testing.T) {
+
+	fmt.Println("Begin - Filesystem Tags Test")
+
+	tags := map[string]string{"owner": "storage-team", "project": "gounity"}
+	err := testConf.fileAPI.SetFilesystemTags(ctx, fsID, tags)
+	if err != nil {
+		t.Fatalf("Set filesystem tags failed: %v", err)
+	}
+
+	readTags, err := testConf.fileAPI.GetFilesystemTags(ctx, fsID)
+	if err != nil {
+		t.Fatalf("Get filesystem tags failed: %v", err)
+	}
+	if len(readTags) != len(tags) || readTags["owner"] != tags["owner"] || readTags["project"] != tags["project"] {
+		t.Fatalf("Get filesystem tags returned %v, expected %v", readTags, tags)
+	}
+
+	filesystem, err := testConf.fileAPI.FindFilesystemByID(ctx, fsID)
+	if err != nil {
+		t.Fatalf("Find filesystem by Id failed: %v", err)
+	}
+	if !strings.HasPrefix(filesystem.FileContent.Description, "Unit test resource") {
+		t.Fatalf("Set filesystem tags did not preserve the description: %s", filesystem.FileContent.Description)
+	}
+
+	//The description and the tags are modified independently
+	err = testConf.fileAPI.ModifyFilesystemDescription(ctx, fsID, "Unit test resource modified")
+	if err != nil {
+		t.Fatalf("Modify filesystem description failed: %v", err)
+	}
+	description, err := testConf.fileAPI.GetFilesystemDescription(ctx, fsID)
+	if err != nil || description != "Unit test resource modified" {
+		t.Fatalf("Get filesystem description returned %s: %v", description, err)
+	}
+	readTags, err = testConf.fileAPI.GetFilesystemTags(ctx, fsID)
+	if err != nil || len(readTags) != len(tags) || readTags["owner"] != tags["owner"] {
+		t.Fatalf("Modify filesystem description did not preserve the tags: %v %v", readTags, err)
+	}
+
+	tags["owner"] = "platform-team"
+	err = testConf.fileAPI.SetFilesystemTags(ctx, fsID, tags)
+	if err != nil {
+		t.Fatalf("Set filesystem tags failed: %v", err)
+	}
+	description, err = testConf.fileAPI.GetFilesystemDescription(ctx, fsID)
+	if err != nil || description != "Unit test resource modified" {
+		t.Fatalf("Set filesystem tags did not preserve the modified description: %s %v", description, err)
+	}
+	readTags, err = testConf.fileAPI.GetFilesystemTags(ctx, fsID)
+	if err != nil || readTags["owner"] != "platform-team" {
+		t.Fatalf("Get filesystem tags after update returned %v: %v", readTags, err)
+	}
+
+	err = testConf.fileAPI.ModifyFilesystemDescription(ctx, fsID, "")
+	if err != nil {
+		t.Fatalf("Clear filesystem description failed: %v", err)
+	}
+	readTags, err = testConf.fileAPI.GetFilesystemTags(ctx, fsID)
+	if err != nil || len(readTags) != len(tags) {
+		t.Fatalf("Clear filesystem description did not preserve the tags: %v %v", readTags, err)
+	}
+	err = testConf.fileAPI.ModifyFilesystemDescription(ctx, fsID, "Unit test resource")
+	if err != nil {
+		t.Fatalf("Restore filesystem description failed: %v", err)
+	}
+
+	//Negative cases
+	err = testConf.fileAPI.ModifyFilesystemDescription(ctx, fsID, "description "+FilesystemTagsMarker+"{}")
+	if err == nil {
+		t.Fatalf("Modify filesystem description with the tags marker case failed: %v", err)
+	}
+
+	_, err = testConf.fileAPI.GetFilesystemTags(ctx, "")
+	if err == nil {
+		t.Fatalf("Get filesystem tags with empty Id case failed: %v", err)
+	}
+
+	fmt.Println("Filesystem Tags Test Successful")
+}
+
+func filesystemAutoExtendTest(t *testing.T) {
+
+	fmt.Println("Begin - Filesystem Auto Extend Test")
+
+	before, err := testConf.fileAPI.FindFilesystemByID(ctx, fsID)
+	if err != nil {
+		t.Fatalf("Find filesystem by Id failed: %v", err)
+	}
+
+	//The test filesystem is empty, so the high watermark is not reached
+	err = testConf.fileAPI.SetFilesystemAutoExtend(ctx, fsID, 4*before.FileContent.SizeTotal, 90)
+	if err != nil {
+		t.Fatalf("Set filesystem auto extend failed: %v", err)
+	}
+	tags, err := testConf.fileAPI.GetFilesystemTags(ctx, fsID)
+	if err != nil || tags[AutoExtendHighWatermarkTag] != "90" {
+		t.Fatalf("Filesystem auto extend policy not stored: %v %v", tags, err)
+	}
+	err = testConf.fileAPI.ApplyFilesystemAutoExtend(ctx, fsID)
+	if err != nil {
+		t.Fatalf("Apply filesystem auto extend failed: %v", err)
+	}
+	after, err := testConf.fileAPI.FindFilesystemByID(ctx, fsID)
+	if err != nil || after.FileContent.SizeTotal != before.FileContent.SizeTotal {
+		t.Fatalf("Filesystem extended below the high watermark: %v", err)
+	}
+
+	err = testConf.fileAPI.SetFilesystemAutoExtend(ctx, fsID, 0, 0)
+	if err != nil {
+		t.Fatalf("Remove filesystem auto extend failed: %v", err)
+	}
+
+	//Negative cases
+	err = testConf.fileAPI.SetFilesystemAutoExtend(ctx, fsID, 4*before.FileContent.SizeTotal, 0)
+	if err == nil {
+		t.Fatalf("Set filesystem auto extend with invalid high watermark case failed: %v", err)
+	}
+
+	err = testConf.fileAPI.SetFilesystemAutoExtend(ctx, fsID, before.FileContent.SizeTotal/2, 90)
+	if err == nil {
+		t.Fatalf("Set filesystem auto extend with max size smaller than the size case failed: %v", err)
+	}
+
+	fmt.Println("Filesystem Auto Extend Test Successful")
+}
+
+func modifyFilesystemNameTest(t *testing.T) {
+
+	fmt.Println("Begin - Modify Filesystem Name Test")
+
+	err := testConf.fileAPI.ModifyFilesystemName(ctx, fsID, fsName+"-renamed")
+	if err != nil {
+		t.Fatalf("Rename filesystem failed: %v", err)
+	}
+	filesystem, err := testConf.fileAPI.FindFilesystemByID(ctx, fsID)
+	if err != nil || filesystem.FileContent.Name != fsName+"-renamed" {
+		t.Fatalf("Find renamed filesystem failed: %v", err)
+	}
+	err = testConf.fileAPI.ModifyFilesystemName(ctx, fsID, fsName)
+	if err != nil {
+		t.Fatalf("Rename filesystem back failed: %v", err)
+	}
+
+	//Negative cases
+	err = testConf.fileAPI.ModifyFilesystemName(ctx, fsID, " invalid-name")
+	if err == nil {
+		t.Fatal("Rename filesystem with invalid name - Negative case failed")
+	}
+	err = testConf.fileAPI.ModifyFilesystemName(ctx, "", fsName)
+	if err == nil {
+		t.Fatal("Rename filesystem with empty Id - Negative case failed")
+	}
+
+	fmt.Println("Modify Filesystem Name Test Successful")
+}
+
 func deleteFilesystemTest(t *testing.T) {
 
 	fmt.Println("Begin - Delete Filesystem Test")
 
-	err := testConf.fileAPI.DeleteFilesystem(ctx, fsID)
+	err := testConf.fileAPI.DeleteFilesystemAndWait(ctx, fsID, 2*time.Second)
 	if err != nil {
 		t.Fatalf("Delete filesystem failed: %v", err)
 	}
 
+	_, err = testConf.fileAPI.FindFilesystemByName(ctx, fsName)
+	if err == nil {
+		t.Fatal("Filesystem found by name after delete and wait")
+	}
+
 	//@TODO: Add negative cases after export - before unexport
 
 	//Test case :  Delete using invalid fsName/ID
@@ -370,5 +1362,10 @@ func deleteFilesystemTest(t *testing.T) {
 		t.Fatal("Delete filesystem - empty fsID failed")
 	}
 
+	err = testConf.fileAPI.DeleteFilesystemAndWait(ctx, fsIDTemp, 0)
+	if err == nil {
+		t.Fatal("Delete filesystem and wait - invalid poll interval failed")
+	}
+
 	fmt.Println("Delete Filesystem Test Successful")
 }
@@ -12,8 +12,6 @@ import (
 	"net/http"
 	"strings"
 
-	"github.com/dell/gounity/util"
-
 	"github.com/dell/gounity/api"
 	"github.com/dell/gounity/types"
 )
@@ -23,6 +21,12 @@ type Host struct {
 	client *Client
 }
 
+//CHAP secret length constants
+const (
+	CHAPSecretMinLength = 12
+	CHAPSecretMaxLength = 16
+)
+
 //Host not found error variables
 var (
 	ErrorHostNotFound          = errors.New("unable to find host")
@@ -38,7 +42,7 @@ func NewHost(client *Client) *Host {
 
 //FindHostByName Finds the Host by it's name. If the Host is not found, an error will be returned.
 func (h *Host) FindHostByName(ctx context.Context, hostName string) (*types.Host, error) {
-	log := util.GetRunIDLogger(ctx)
+	log := h.client.getLogger(ctx)
 	if len(hostName) == 0 {
 		return nil, errors.New("host Name shouldn't be empty")
 	}
@@ -58,6 +62,27 @@ func (h *Host) FindHostByName(ctx context.Context, hostName string) (*types.Host
 	return hResponse, nil
 }
 
+//FindHostByID Finds the Host by it's Id. If the Host is not found, ErrorHostNotFound will be returned.
+func (h *Host) FindHostByID(ctx context.Context, hostID string) (*types.Host, error) {
+	if len(hostID) == 0 {
+		return nil, errors.New("host Id shouldn't be empty")
+	}
+	hResponse := &types.Host{}
+	err := h.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceWithFieldsURI, api.HostAction, hostID, HostfieldsToQuery), nil, hResponse)
+	if err != nil {
+		if strings.Contains(err.Error(), HostNotFoundErrorCode) {
+			return nil, ErrorHostNotFound
+		}
+		return nil, err
+	}
+	return hResponse, nil
+}
+
+//HostExists Checks whether a Host exists with the given Id or name, without fetching the host
+func (h *Host) HostExists(ctx context.Context, nameOrID string) (bool, error) {
+	return h.client.resourceExists(ctx, api.HostAction, nameOrID)
+}
+
 //CreateHost Create a new Host
 func (h *Host) CreateHost(ctx context.Context, hostName string, tenantID string) (*types.Host, error) {
 	if len(hostName) == 0 {
@@ -132,6 +157,17 @@ func (h *Host) FindHostIPPortByID(ctx context.Context, hostIPID string) (*types.
 	return hostIPResp, nil
 }
 
+//ListHosts lists all the hosts with their IP ports and FC/iSCSI initiators
+func (h *Host) ListHosts(ctx context.Context) ([]types.Host, error) {
+	listHostsResp := &types.ListHosts{}
+	hostsURI := fmt.Sprintf(api.UnityAPIInstanceTypeResourcesWithFields, api.HostAction, HostListDisplayFields)
+	err := h.client.executeWithRetryAuthenticate(ctx, http.MethodGet, hostsURI, nil, listHostsResp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list hosts. Error: %v", err)
+	}
+	return listHostsResp.Hosts, nil
+}
+
 // ListHostInitiators lists all host initiators
 func (h *Host) ListHostInitiators(ctx context.Context) ([]types.HostInitiator, error) {
 	listInitiatorResp := &types.ListHostInitiator{}
@@ -183,7 +219,7 @@ func (h *Host) FindHostInitiatorByID(ctx context.Context, wwnOrIqn string) (*typ
 
 //CreateHostInitiator - Create Host Initiator
 func (h *Host) CreateHostInitiator(ctx context.Context, hostID, wwnOrIqn string, initiatorType types.InitiatorType) (*types.HostInitiator, error) {
-	log := util.GetRunIDLogger(ctx)
+	log := h.client.getLogger(ctx)
 	if len(hostID) == 0 {
 		return nil, errors.New("host ID shouldn't be empty")
 	}
@@ -292,3 +328,59 @@ func (h *Host) FindTenants(ctx context.Context) (*types.TenantInfo, error) {
 	}
 	return tenantsResp, nil
 }
+
+//SetISCSICHAP - Set the array wide (forward global) iSCSI CHAP credentials initiators must use to log in.
+//Empty username and secret disable the CHAP requirement. The secret is never logged.
+func (h *Host) SetISCSICHAP(ctx context.Context, username, secret string) error {
+	log := h.client.getLogger(ctx)
+	if err := validateCHAPCredentials(username, secret); err != nil {
+		return err
+	}
+	iscsiSettingsReq := &types.ISCSISettingsModifyParam{
+		IsForwardCHAPRequired:     username != "",
+		ForwardGlobalCHAPUserName: username,
+		ForwardGlobalCHAPSecret:   secret,
+	}
+	err := h.client.executeWithRetryAuthenticate(ctx, http.MethodPost, api.UnityModifyISCSISettingsURI, iscsiSettingsReq, nil)
+	if err != nil {
+		return fmt.Errorf("set iSCSI CHAP failed. Error: %v", err)
+	}
+	log.Debugf("Set iSCSI CHAP for user: %s successful", username)
+	return nil
+}
+
+//SetHostInitiatorCHAP - Set the CHAP credentials of a host iSCSI initiator. Empty username and secret remove them.
+//The secret is never logged.
+func (h *Host) SetHostInitiatorCHAP(ctx context.Context, initiatorID, username, secret string) error {
+	log := h.client.getLogger(ctx)
+	if initiatorID == "" {
+		return errors.New("Initiator ID shouldn't be null")
+	}
+	if err := validateCHAPCredentials(username, secret); err != nil {
+		return err
+	}
+	hostInitiatorReq := &types.HostInitiatorCHAPModifyParam{
+		ChapUserName: username,
+		ChapSecret:   secret,
+	}
+	err := h.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityModifyHostInitiators, initiatorID), hostInitiatorReq, nil)
+	if err != nil {
+		return fmt.Errorf("set CHAP on host initiator %s failed. Error: %v", initiatorID, err)
+	}
+	log.Debugf("Set CHAP for user: %s on host initiator %s successful", username, initiatorID)
+	return nil
+}
+
+//validateCHAPCredentials checks the username and secret are both set, or both empty, and the secret length
+func validateCHAPCredentials(username, secret string) error {
+	if username == "" && secret == "" {
+		return nil
+	}
+	if username == "" || secret == "" {
+		return errors.New("CHAP username and secret should be both set or both empty")
+	}
+	if len(secret) < CHAPSecretMinLength || len(secret) > CHAPSecretMaxLength {
+		return fmt.Errorf("CHAP secret should be %d to %d characters", CHAPSecretMinLength, CHAPSecretMaxLength)
+	}
+	return nil
+}
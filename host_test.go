@@ -20,6 +20,7 @@ var iqnInitiator *types.HostInitiator
 var tenantID string
 
 func TestHost(t *testing.T) {
+	requireArray(t)
 	now := time.Now()
 	timeStamp := now.Format("20060102150405")
 	hostName = "Unit-test-host-" + timeStamp
@@ -28,6 +29,7 @@ func TestHost(t *testing.T) {
 
 	createHostTest(t)
 	findHostByNameTest(t)
+	listHostsTest(t)
 	createHostIPPortTest(t)
 	findHostIPPortByIDTest(t)
 	createHostInitiatorTest(t)
@@ -38,6 +40,7 @@ func TestHost(t *testing.T) {
 	modifyHostInitiatorByIDTest(t)
 	findHostInitiatorPathByIDTest(t)
 	findFcPortByIDTest(t)
+	setCHAPTest(t)
 	deleteHostTest(t)
 }
 
@@ -65,11 +68,21 @@ func findHostByNameTest(t *testing.T) {
 
 	fmt.Println("Begin - Find Host by name Test")
 
-	_, err := testConf.hostAPI.FindHostByName(ctx, hostName)
+	host, err := testConf.hostAPI.FindHostByName(ctx, hostName)
 	if err != nil {
 		t.Fatalf("Find Host failed: %v", err)
 	}
 
+	_, err = testConf.hostAPI.FindHostByID(ctx, host.HostContent.ID)
+	if err != nil {
+		t.Fatalf("Find Host by Id failed: %v", err)
+	}
+
+	exists, err := testConf.hostAPI.HostExists(ctx, hostName)
+	if err != nil || !exists {
+		t.Fatalf("Host exists by name failed: %v", err)
+	}
+
 	//Negative test cases
 	hostNameTemp := ""
 	_, err = testConf.hostAPI.FindHostByName(ctx, hostNameTemp)
@@ -83,9 +96,40 @@ func findHostByNameTest(t *testing.T) {
 		t.Fatalf("Find Host with invalid hostName - Negative case failed")
 	}
 
+	_, err = testConf.hostAPI.FindHostByID(ctx, "dummy_host_1")
+	if err != ErrorHostNotFound {
+		t.Fatalf("Find Host with invalid host Id - Negative case failed: %v", err)
+	}
+
+	exists, err = testConf.hostAPI.HostExists(ctx, "dummy_host_1")
+	if err != nil || exists {
+		t.Fatalf("Host exists with invalid host Id - Negative case failed: %v", err)
+	}
+
 	fmt.Println("Find Host by name Successful")
 }
 
+func listHostsTest(t *testing.T) {
+
+	fmt.Println("Begin - List Hosts Test")
+	list, err := testConf.hostAPI.ListHosts(ctx)
+	fmt.Println("List Hosts", len(list), err)
+	if err != nil {
+		t.Fatalf("ListHosts error: %v", err)
+	}
+
+	found := false
+	for _, host := range list {
+		found = found || host.HostContent.Name == hostName
+	}
+	if !found {
+		t.Fatalf("ListHosts did not return host: %s", hostName)
+	}
+
+	fmt.Println("List Hosts Test Successful")
+
+}
+
 func createHostIPPortTest(t *testing.T) {
 
 	fmt.Println("Begin - Create Host IP Port Test")
@@ -369,6 +413,29 @@ func findFcPortByIDTest(t *testing.T) {
 	fmt.Println("Find FC Port Test Successful")
 }
 
+func setCHAPTest(t *testing.T) {
+
+	fmt.Println("Begin - Set CHAP Test")
+
+	//Negative test cases
+	err := testConf.hostAPI.SetISCSICHAP(ctx, "chap-user", "short")
+	if err == nil {
+		t.Fatalf("Set iSCSI CHAP with short secret - Negative case failed")
+	}
+
+	err = testConf.hostAPI.SetISCSICHAP(ctx, "chap-user", "")
+	if err == nil {
+		t.Fatalf("Set iSCSI CHAP without secret - Negative case failed")
+	}
+
+	err = testConf.hostAPI.SetHostInitiatorCHAP(ctx, "", "chap-user", "chap-secret-12")
+	if err == nil {
+		t.Fatalf("Set host initiator CHAP with empty initiator Id - Negative case failed")
+	}
+
+	fmt.Println("Set CHAP Test Successful")
+}
+
 func deleteHostTest(t *testing.T) {
 
 	fmt.Println("Begin - Delete Host Test")
@@ -7,8 +7,10 @@ package gounity
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/dell/gounity/util"
 
@@ -21,6 +23,12 @@ type Ipinterface struct {
 	client *Client
 }
 
+//File interface role constants
+const (
+	FileInterfaceRoleProduction = 0
+	FileInterfaceRoleBackup     = 1
+)
+
 //NewIPInterface returns IP interface
 func NewIPInterface(client *Client) *Ipinterface {
 	return &Ipinterface{client}
@@ -29,7 +37,7 @@ func NewIPInterface(client *Client) *Ipinterface {
 //ListIscsiIPInterfaces - List the IpnInterfaces configured on the array
 func (f *Ipinterface) ListIscsiIPInterfaces(ctx context.Context) ([]types.IPInterfaceEntries, error) {
 
-	log := util.GetRunIDLogger(ctx)
+	log := f.client.getLogger(ctx)
 	hResponse := &types.ListIPInterfaces{}
 	log.Debugf("URI: "+api.UnityAPIInstanceTypeResourcesWithFields, api.IPInterface, IscsiIPFields)
 	err := f.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIInstanceTypeResourcesWithFields, api.IPInterface, IscsiIPFields), nil, hResponse)
@@ -45,3 +53,94 @@ func (f *Ipinterface) ListIscsiIPInterfaces(ctx context.Context) ([]types.IPInte
 	}
 	return iscsiInterfaces, nil
 }
+
+//ListFileInterfaces - List a page of maxEntries file interfaces (DefaultListPageSize if 0) of the given NAS server, with
+//their IP address, port, role, netmask/prefix length and gateway. The returned token, empty on the last page, is passed
+//back as nextToken to list the next page.
+func (f *Ipinterface) ListFileInterfaces(ctx context.Context, nasServerID, nextToken string, maxEntries int) ([]types.FileInterface, string, error) {
+	if len(nasServerID) == 0 {
+		return nil, "", errors.New("NAS Server Id shouldn't be empty")
+	}
+	interfacesResp := &types.ListFileInterfaces{}
+	token, err := f.client.listPage(ctx, api.FileInterfaceAction, strings.Split(FileInterfaceDisplayFields, ","), fmt.Sprintf("nasServer.id eq \"%s\"", nasServerID), nextToken, maxEntries, interfacesResp)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to list file interfaces of NAS Server: %s. Error: %w", nasServerID, err)
+	}
+	return interfacesResp.Entries, token, nil
+}
+
+//listFileInterfaces lists all the file interfaces of the given NAS server, a NAS server has a handful of them at most
+func (f *Ipinterface) listFileInterfaces(ctx context.Context, nasServerID string) ([]types.FileInterface, error) {
+	if len(nasServerID) == 0 {
+		return nil, errors.New("NAS Server Id shouldn't be empty")
+	}
+	interfacesResp := &types.ListFileInterfaces{}
+	err := f.client.QueryInstances(ctx, api.FileInterfaceAction, strings.Split(FileInterfaceDisplayFields, ","), fmt.Sprintf("nasServer.id eq \"%s\"", nasServerID), interfacesResp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list file interfaces of NAS Server: %s. Error: %w", nasServerID, err)
+	}
+	return interfacesResp.Entries, nil
+}
+
+//ListNASServerRoutes - List the routes of the file interfaces of the given NAS server
+func (f *Ipinterface) ListNASServerRoutes(ctx context.Context, nasServerID string) ([]types.Route, error) {
+	if len(nasServerID) == 0 {
+		return nil, errors.New("NAS Server Id shouldn't be empty")
+	}
+	interfacesResp := &types.ListFileInterfaces{}
+	err := f.client.QueryInstances(ctx, api.FileInterfaceAction, []string{"id", "nasServer"}, fmt.Sprintf("nasServer.id eq \"%s\"", nasServerID), interfacesResp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list file interfaces of NAS Server: %s. Error: %v", nasServerID, err)
+	}
+	interfaceIDs := make(map[string]bool)
+	for _, fileInterface := range interfacesResp.Entries {
+		interfaceIDs[fileInterface.FileInterfaceContent.ID] = true
+	}
+
+	routesResp := &types.ListRoutes{}
+	err = f.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIInstanceTypeResourcesWithFields, api.RouteAction, RouteDisplayFields), nil, routesResp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list routes of NAS Server: %s. Error: %v", nasServerID, err)
+	}
+	routes := []types.Route{}
+	for _, route := range routesResp.Entries {
+		if interfaceIDs[route.RouteContent.IPInterface.ID] {
+			routes = append(routes, route)
+		}
+	}
+	return routes, nil
+}
+
+//AddNASServerRoute - Add a route through the given gateway to the destination network (netmask for IPv4, prefix length
+//for IPv6) on the given file interface of a NAS server
+func (f *Ipinterface) AddNASServerRoute(ctx context.Context, fileInterfaceID, destination, netmask string, v6PrefixLength int, gateway string) (*types.Route, error) {
+	if len(fileInterfaceID) == 0 {
+		return nil, errors.New("file interface Id shouldn't be empty")
+	}
+	if _, err := util.ParseIPAddress(destination); err != nil {
+		return nil, fmt.Errorf("invalid route destination %s. Error: %v", destination, err)
+	}
+	if _, err := util.ParseIPAddress(gateway); err != nil {
+		return nil, fmt.Errorf("invalid route gateway %s. Error: %v", gateway, err)
+	}
+	if util.IsIPv6Address(destination) {
+		if err := util.ValidateIPPrefixLength(destination, v6PrefixLength); err != nil {
+			return nil, err
+		}
+	} else if _, err := util.ParseIPAddress(netmask); err != nil {
+		return nil, fmt.Errorf("invalid route netmask %s. Error: %v", netmask, err)
+	}
+	routeReq := types.RouteCreateParam{
+		Interface:      &types.StorageResourceParam{ID: fileInterfaceID},
+		Destination:    destination,
+		Netmask:        netmask,
+		V6PrefixLength: v6PrefixLength,
+		Gateway:        gateway,
+	}
+	routeResp := &types.Route{}
+	err := f.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityAPIInstanceTypeResources, api.RouteAction), routeReq, routeResp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to add route to %s via %s on file interface: %s. Error: %v", destination, gateway, fileInterfaceID, err)
+	}
+	return routeResp, nil
+}
@@ -7,6 +7,7 @@ import (
 )
 
 func TestListIPInterfaces(t *testing.T) {
+	requireArray(t)
 	ctx := context.Background()
 
 	ipInterfaces, err := testConf.ipinterfaceAPI.ListIscsiIPInterfaces(ctx)
@@ -20,3 +21,48 @@ func TestListIPInterfaces(t *testing.T) {
 	}
 	fmt.Println("List Ip Interfaces success")
 }
+
+func TestListFileInterfaces(t *testing.T) {
+	requireArray(t)
+	ctx := context.Background()
+
+	fileInterfaces, token, err := testConf.ipinterfaceAPI.ListFileInterfaces(ctx, testConf.nasServer, "", 0)
+	if err != nil || token != "" {
+		t.Fatalf("List file interfaces failed: %s %v", token, err)
+	}
+	for _, fileInterface := range fileInterfaces {
+		fmt.Println("File interface address: ", fileInterface.FileInterfaceContent.IPAddress, " role: ", fileInterface.FileInterfaceContent.Role)
+	}
+
+	//Negative cases
+	_, _, err = testConf.ipinterfaceAPI.ListFileInterfaces(ctx, "", "", 0)
+	if err == nil {
+		t.Fatalf("List file interfaces with empty Id case - failed: %v", err)
+	}
+	fmt.Println("List file interfaces success")
+}
+
+func TestNASServerRoutes(t *testing.T) {
+	requireArray(t)
+	ctx := context.Background()
+
+	routes, err := testConf.ipinterfaceAPI.ListNASServerRoutes(ctx, testConf.nasServer)
+	if err != nil {
+		t.Fatalf("List NAS Server routes failed: %v", err)
+	}
+	for _, route := range routes {
+		fmt.Println("Route destination: ", route.RouteContent.Destination, " gateway: ", route.RouteContent.Gateway)
+	}
+
+	//Negative cases
+	_, err = testConf.ipinterfaceAPI.ListNASServerRoutes(ctx, "")
+	if err == nil {
+		t.Fatalf("List NAS Server routes with empty Id case - failed: %v", err)
+	}
+
+	_, err = testConf.ipinterfaceAPI.AddNASServerRoute(ctx, "dummy_if_1", "10.0.0.0", "255.255.255.0", 0, "dummy-gateway")
+	if err == nil {
+		t.Fatalf("Add NAS Server route with invalid gateway case - failed: %v", err)
+	}
+	fmt.Println("NAS Server routes success")
+}
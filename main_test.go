@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strconv"
 	"strings"
@@ -26,13 +27,16 @@ type testConfig struct {
 	iqn             string
 	hostIOLimitName string
 	nasServer       string
+	client          *Client
 	volumeAPI       *Volume
 	hostAPI         *Host
 	poolAPI         *Storagepool
 	snapAPI         *Snapshot
 	ipinterfaceAPI  *Ipinterface
-	fileAPI         *Filesystem
+	fileAPI         Filesystem
 	metricsAPI      *Metrics
+	replicationAPI  *Replication
+	alertAPI        *Alert
 }
 
 var testConf *testConfig
@@ -43,6 +47,11 @@ func TestMain(m *testing.M) {
 
 	// for this tutorial, we will hard code it to config.txt
 	testProp, err := readTestProperties("test.properties")
+	if os.IsNotExist(err) {
+		//Only the tests not needing an array (Ex: against httptest servers) run, see requireArray
+		fmt.Println("test.properties not found, the tests against the array are skipped")
+		os.Exit(m.Run())
+	}
 	if err != nil {
 		panic("The system cannot find the file specified")
 	}
@@ -77,6 +86,7 @@ func TestMain(m *testing.M) {
 	testClient := getTestClient(ctx, testConf.unityEndPoint, testConf.username, testConf.password, testConf.unityEndPoint, insecure)
 	testConf.wwns = strings.Split(wwnStr, ",")
 
+	testConf.client = testClient
 	testConf.hostAPI = NewHost(testClient)
 	testConf.poolAPI = NewStoragePool(testClient)
 	testConf.snapAPI = NewSnapshot(testClient)
@@ -84,12 +94,33 @@ func TestMain(m *testing.M) {
 	testConf.ipinterfaceAPI = NewIPInterface(testClient)
 	testConf.fileAPI = NewFilesystem(testClient)
 	testConf.metricsAPI = NewMetrics(testClient)
+	testConf.replicationAPI = NewReplication(testClient)
+	testConf.alertAPI = NewAlert(testClient)
 
 	code := m.Run()
 	fmt.Println("------------End of TestMain--------------")
 	os.Exit(code)
 }
 
+//requireArray skips the test when no array is configured in test.properties
+func requireArray(t *testing.T) {
+	if testConf == nil {
+		t.Skip("no array configured in test.properties")
+	}
+}
+
+//newTestServerClient returns a client of a httptest server serving the requests with the given handler, for the tests
+//not needing an array. The server should be closed by the caller.
+func newTestServerClient(t *testing.T, handler http.HandlerFunc) (*Client, *httptest.Server) {
+	server := httptest.NewServer(handler)
+	client, err := NewClientWithArgs(context.Background(), server.URL, true)
+	if err != nil {
+		server.Close()
+		t.Fatalf("Create client failed: %v", err)
+	}
+	return client, server
+}
+
 func getTestClient(ctx context.Context, url, username, password, endpoint string, insecure bool) *Client {
 	fmt.Println("Test:", url, username, password)
 
@@ -17,12 +17,28 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/dell/gounity/api"
 	"github.com/dell/gounity/types"
-	"github.com/dell/gounity/util"
 )
 
+//Real-time metrics query settings used by QueryMetricRealTime
+const (
+	RealTimeMetricsQueryInterval    = 5 // seconds, the minimum interval supported by Unity
+	RealTimeMetricsQueryMaxAttempts = 3
+)
+
+//Historical metrics query settings used by QueryMetricHistorical
+const (
+	HistoricalMetricsPageSize = 1000
+	metricTimestampFormat     = "2006-01-02T15:04:05.000Z"
+)
+
+//HistoricalMetricsIntervals are the sampling intervals (in seconds) Unity keeps historical metrics for
+var HistoricalMetricsIntervals = []int{60, 300, 3600, 14400}
+
 //Metrics structure
 type Metrics struct {
 	client *Client
@@ -37,7 +53,7 @@ func NewMetrics(client *Client) *Metrics {
 //or enumerating metrics. This will take a bit of time to complete.
 // - /api/types/metric/instances?compact=true&filter=isRealtimeAvailable eq true
 func (m *Metrics) GetAllRealTimeMetricPaths(ctx context.Context) error {
-	log := util.GetRunIDLogger(ctx)
+	log := m.client.getLogger(ctx)
 	filter := "isRealtimeAvailable eq true"
 
 	query := fmt.Sprintf("%s&compact=true", url.QueryEscape(filter))
@@ -65,7 +81,7 @@ func (m *Metrics) GetAllRealTimeMetricPaths(ctx context.Context) error {
 // - The MetricCollection should exist already or you can create one using CreateXXXMetricsQuery.
 // - Example: GET /api/types/metricQueryResult/instances?filter=queryId eq 37
 func (m *Metrics) GetMetricsCollection(ctx context.Context, queryID int) (*types.MetricQueryResult, error) {
-	log := util.GetRunIDLogger(ctx)
+	log := m.client.getLogger(ctx)
 
 	filter := fmt.Sprintf("queryId eq %d", queryID)
 	queryURI := fmt.Sprintf(api.UnityInstancesFilter, api.UnityMetricQueryResult, url.QueryEscape(filter))
@@ -88,7 +104,7 @@ func (m *Metrics) GetMetricsCollection(ctx context.Context, queryID int) (*types
 //               "interval": 5
 //            }
 func (m *Metrics) CreateRealTimeMetricsQuery(ctx context.Context, metricPaths []string, interval int) (*types.MetricQueryCreateResponse, error) {
-	log := util.GetRunIDLogger(ctx)
+	log := m.client.getLogger(ctx)
 
 	createURI := fmt.Sprintf(api.UnityAPIInstanceTypeResources, api.UnityMetricRealTimeQuery)
 	log.Info("CreateRealTimeMetricQuery: ", createURI)
@@ -109,7 +125,7 @@ func (m *Metrics) CreateRealTimeMetricsQuery(ctx context.Context, metricPaths []
 //DeleteRealTimeMetricsQuery deletes the MetricRealTime Collection of the given queryID.
 // - Example: DELETE /api/instances/metricRealTimeQuery/37
 func (m *Metrics) DeleteRealTimeMetricsQuery(ctx context.Context, queryID int) error {
-	log := util.GetRunIDLogger(ctx)
+	log := m.client.getLogger(ctx)
 	deleteURI := fmt.Sprintf(api.UnityAPIGetResourceURI, api.UnityMetricRealTimeQuery, strconv.Itoa(queryID))
 	log.Info("DeleteRealTimeMetricsQuery:", deleteURI)
 
@@ -120,3 +136,137 @@ func (m *Metrics) DeleteRealTimeMetricsQuery(ctx context.Context, queryID int) e
 
 	return nil
 }
+
+//QueryMetricRealTime returns the current values of the given metric paths.
+// - A temporary MetricRealTime Collection is created, read once the first samples are available and deleted.
+// - 'resourceFilter' restricts the values to a single resource Id (Ex: fs_1, sv_1); pass "" to get all values.
+// - Example paths: "sp.*.storage.filesystem.*.readsRate", "sp.*.storage.filesystem.*.writesRate"
+func (m *Metrics) QueryMetricRealTime(ctx context.Context, paths []string, resourceFilter string) ([]types.MetricValue, error) {
+	log := m.client.getLogger(ctx)
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("metric paths shouldn't be empty")
+	}
+
+	query, err := m.CreateRealTimeMetricsQuery(ctx, paths, RealTimeMetricsQueryInterval)
+	if err != nil {
+		return nil, err
+	}
+	queryID := query.Content.ID
+	defer func() {
+		if err := m.DeleteRealTimeMetricsQuery(ctx, queryID); err != nil {
+			log.Warnf("Unable to delete real time metrics query %d: %v", queryID, err)
+		}
+	}()
+
+	for attempt := 0; attempt < RealTimeMetricsQueryMaxAttempts; attempt++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(RealTimeMetricsQueryInterval * time.Second):
+		}
+
+		result, err := m.GetMetricsCollection(ctx, queryID)
+		if err != nil {
+			return nil, err
+		}
+		if len(result.Entries) > 0 {
+			return flattenMetricResults(result.Entries, resourceFilter), nil
+		}
+		log.Debugf("No samples available yet for real time metrics query %d", queryID)
+	}
+
+	return nil, fmt.Errorf("no samples received for real time metrics query %d", queryID)
+}
+
+//flattenMetricResults converts the per storage processor values of the metric results into a flat list.
+//Values are either a number per storage processor or a map of resource Id to number per storage processor.
+func flattenMetricResults(entries []types.MetricResultEntry, resourceFilter string) []types.MetricValue {
+	metricValues := []types.MetricValue{}
+	for _, entry := range entries {
+		for sp, value := range entry.Content.Values {
+			resourceValues, ok := value.(map[string]interface{})
+			if !ok {
+				if resourceFilter == "" {
+					metricValues = append(metricValues, newMetricValue(entry.Content, sp, "", value))
+				}
+				continue
+			}
+			for resourceID, resourceValue := range resourceValues {
+				if resourceFilter != "" && resourceID != resourceFilter {
+					continue
+				}
+				metricValues = append(metricValues, newMetricValue(entry.Content, sp, resourceID, resourceValue))
+			}
+		}
+	}
+	return metricValues
+}
+
+func newMetricValue(result types.MetricResult, sp, resourceID string, value interface{}) types.MetricValue {
+	metricValue := types.MetricValue{
+		Path:          result.Path,
+		Timestamp:     result.Timestamp,
+		SP:            sp,
+		ResourceID:    resourceID,
+		RawValue:      value,
+		IsNumberValue: true,
+	}
+	switch v := value.(type) {
+	case float64:
+		metricValue.Value = v
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		metricValue.Value = f
+		metricValue.IsNumberValue = err == nil
+	default:
+		metricValue.IsNumberValue = false
+	}
+	return metricValue
+}
+
+//QueryMetricHistorical returns the historical values of the given metric paths between startTime and endTime.
+// - 'interval' is the sampling interval in seconds and must be one of HistoricalMetricsIntervals.
+// - All pages of the result are read, large time windows therefore result in multiple requests.
+// - Example: GET /api/types/metricValue/instances?filter=path EQ "sp.*.cpu.summary.busyTicks" AND interval EQ 60 AND
+//            timestamp GE "2021-04-08T13:00:00.000Z" AND timestamp LE "2021-04-08T14:00:00.000Z"&per_page=1000&page=1
+func (m *Metrics) QueryMetricHistorical(ctx context.Context, paths []string, startTime, endTime time.Time, interval int) ([]types.MetricValue, error) {
+	log := m.client.getLogger(ctx)
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("metric paths shouldn't be empty")
+	}
+	if !endTime.After(startTime) {
+		return nil, fmt.Errorf("end time %v should be after start time %v", endTime, startTime)
+	}
+	validInterval := false
+	for _, i := range HistoricalMetricsIntervals {
+		validInterval = validInterval || i == interval
+	}
+	if !validInterval {
+		return nil, fmt.Errorf("invalid interval %d, valid intervals are %v", interval, HistoricalMetricsIntervals)
+	}
+
+	pathFilters := make([]string, 0, len(paths))
+	for _, path := range paths {
+		pathFilters = append(pathFilters, fmt.Sprintf("path EQ \"%s\"", path))
+	}
+	filter := fmt.Sprintf("(%s) AND interval EQ %d AND timestamp GE \"%s\" AND timestamp LE \"%s\"",
+		strings.Join(pathFilters, " OR "), interval, startTime.UTC().Format(metricTimestampFormat), endTime.UTC().Format(metricTimestampFormat))
+
+	metricValues := []types.MetricValue{}
+	for page := 1; ; page++ {
+		queryURI := fmt.Sprintf(api.UnityInstancesFilter+"&per_page=%d&page=%d", api.UnityMetricValue, url.QueryEscape(filter), HistoricalMetricsPageSize, page)
+		log.Debug("QueryMetricHistorical: ", queryURI)
+
+		result := &types.MetricValueResult{}
+		err := m.client.executeWithRetryAuthenticate(ctx, http.MethodGet, queryURI, nil, result)
+		if err != nil {
+			return nil, err
+		}
+		metricValues = append(metricValues, flattenMetricResults(result.Entries, "")...)
+		if len(result.Entries) < HistoricalMetricsPageSize {
+			break
+		}
+	}
+
+	return metricValues, nil
+}
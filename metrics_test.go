@@ -23,9 +23,64 @@ import (
 )
 
 func TestMetrics(t *testing.T) {
+	requireArray(t)
 	ctx = context.Background()
 
 	getVolumeMetrics(t)
+	queryMetricRealTime(t)
+	queryMetricHistorical(t)
+}
+
+func queryMetricHistorical(t *testing.T) {
+	fmt.Println("Begin - Query Historical Metrics")
+
+	paths := []string{
+		"sp.*.cpu.summary.busyTicks",
+		"sp.*.cpu.summary.idleTicks",
+	}
+	endTime := time.Now()
+	startTime := endTime.Add(-1 * time.Hour)
+	values, err := testConf.metricsAPI.QueryMetricHistorical(ctx, paths, startTime, endTime, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Printf("Received %d historical metric values\n", len(values))
+
+	//Negative cases
+	_, err = testConf.metricsAPI.QueryMetricHistorical(ctx, paths, startTime, endTime, 7)
+	if err == nil {
+		t.Fatal("Query historical metrics with invalid interval - Negative case failed")
+	}
+	_, err = testConf.metricsAPI.QueryMetricHistorical(ctx, paths, endTime, startTime, 60)
+	if err == nil {
+		t.Fatal("Query historical metrics with end time before start time - Negative case failed")
+	}
+
+	fmt.Println("End - Query Historical Metrics")
+}
+
+func queryMetricRealTime(t *testing.T) {
+	fmt.Println("Begin - Query Realtime Metrics")
+
+	paths := []string{
+		"sp.*.storage.filesystem.*.readsRate",
+		"sp.*.storage.filesystem.*.writesRate",
+	}
+	values, err := testConf.metricsAPI.QueryMetricRealTime(ctx, paths, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, value := range values {
+		fmt.Printf("Path: %s SP: %s Resource: %s Value: %v\n", value.Path, value.SP, value.ResourceID, value.Value)
+	}
+
+	//Negative case
+	_, err = testConf.metricsAPI.QueryMetricRealTime(ctx, []string{}, "")
+	if err == nil {
+		t.Fatal("Query realtime metrics with empty paths - Negative case failed")
+	}
+
+	fmt.Println("End - Query Realtime Metrics")
 }
 
 func getVolumeMetrics(t *testing.T) {
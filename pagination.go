@@ -0,0 +1,90 @@
+package gounity
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/dell/gounity/api"
+	"github.com/dell/gounity/types"
+)
+
+//DefaultListPageSize stores the number of entries of a page when the caller of a paged list doesn't request any
+const DefaultListPageSize = 1000
+
+//ErrInvalidPageToken stores error for a page token not returned by a paged list
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+//pageToken is the page state encoded in the opaque token returned by the paged lists to resume the listing
+type pageToken struct {
+	Page    int `json:"page"`
+	PerPage int `json:"perPage"`
+}
+
+//encodePageToken returns the opaque token of the given page
+func encodePageToken(token pageToken) string {
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+//decodePageToken returns the page of the given opaque token, the first page of maxEntries entries when the token is
+//empty. The page size of a token is kept while paging, to not skip or repeat entries.
+func decodePageToken(token string, maxEntries int) (pageToken, error) {
+	if token == "" {
+		if maxEntries <= 0 {
+			maxEntries = DefaultListPageSize
+		}
+		return pageToken{Page: 1, PerPage: maxEntries}, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return pageToken{}, fmt.Errorf("%w: %s", ErrInvalidPageToken, token)
+	}
+	page := pageToken{}
+	if err = json.Unmarshal(data, &page); err != nil || page.Page < 1 || page.PerPage < 1 {
+		return pageToken{}, fmt.Errorf("%w: %s", ErrInvalidPageToken, token)
+	}
+	return page, nil
+}
+
+//listPage lists a page of the instances of the given resource type matching the filter into dest (a list struct with
+//entries) and returns the token of the next page, empty when the listing is done. The page is resumed from the token
+//returned by the previous call, the first page of maxEntries entries is listed when the token is empty.
+func (c *Client) listPage(ctx context.Context, resType string, fields []string, filter, token string, maxEntries int, dest interface{}) (string, error) {
+	page, err := decodePageToken(token, maxEntries)
+	if err != nil {
+		return "", err
+	}
+	query := url.Values{}
+	if len(fields) > 0 {
+		query.Set("fields", strings.Join(fields, ","))
+	}
+	if len(filter) > 0 {
+		query.Set("filter", filter)
+	}
+	query.Set("per_page", fmt.Sprint(page.PerPage))
+	query.Set("page", fmt.Sprint(page.Page))
+
+	resp := json.RawMessage{}
+	err = c.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIInstanceTypeResources, resType)+"?"+query.Encode(), nil, &resp)
+	if err != nil {
+		return "", fmt.Errorf("unable to list page %d of %s instances. Error: %w", page.Page, resType, err)
+	}
+	if err = json.Unmarshal(resp, dest); err != nil {
+		return "", fmt.Errorf("unable to decode page %d of %s instances. Error: %v", page.Page, resType, err)
+	}
+	pageInfo := types.PageInfo{}
+	if err = json.Unmarshal(resp, &pageInfo); err != nil {
+		return "", fmt.Errorf("unable to decode page %d of %s instances. Error: %v", page.Page, resType, err)
+	}
+
+	if !pageInfo.HasNext() && page.Page*page.PerPage >= pageInfo.EntryCount {
+		return "", nil
+	}
+	return encodePageToken(pageToken{Page: page.Page + 1, PerPage: page.PerPage}), nil
+}
@@ -0,0 +1,74 @@
+package gounity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/dell/gounity/api"
+	"github.com/dell/gounity/types"
+)
+
+//ErrQuotaExceeded is returned when a request fails because a tree or user quota limit is exceeded, as opposed to the
+//storage pool running out of space. The quota details are available through errors.As with *QuotaExceededError.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+//quotaURIRegex matches the URI of a request on a tree or user quota, capturing the quota Id
+var quotaURIRegex = regexp.MustCompile(`/api/instances/(` + api.TreeQuotaAction + `|` + api.UserQuotaAction + `)/([^/?]+)`)
+
+//QuotaExceededError is returned when a request fails because a quota limit is exceeded. The quota Id and limits are
+//known when the request is made on the quota (Ex: a limit lowered below the used space), empty otherwise.
+type QuotaExceededError struct {
+	QuotaID   string
+	HardLimit uint64
+	SoftLimit uint64
+	Err       error
+}
+
+//Error returns the quota details followed by the underlying error
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%v (quota: %s, hard limit: %d, soft limit: %d). Error: %v", ErrQuotaExceeded, e.QuotaID, e.HardLimit, e.SoftLimit, e.Err)
+}
+
+//Unwrap returns the underlying error (Ex: the Unity error, *types.Error)
+func (e *QuotaExceededError) Unwrap() error {
+	return e.Err
+}
+
+//Is returns true for ErrQuotaExceeded
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+//isQuotaExceeded returns true for the errors returned by the array when a quota limit is exceeded
+func isQuotaExceeded(e *types.Error) bool {
+	for _, message := range e.ErrorContent.Message {
+		text := strings.ToLower(message.EnUS)
+		if strings.Contains(text, "quota") && strings.Contains(text, "exceed") {
+			return true
+		}
+	}
+	return false
+}
+
+//quotaExceededError returns a QuotaExceededError for the failed request, with the Id and limits of the quota when the
+//request is made on a quota. The limits are read with the headers of the failed request, without the retry and request
+//limit of executeWithRetryAuthenticate which is running the request.
+func (c *Client) quotaExceededError(ctx context.Context, uri string, headers map[string]string, err error) *QuotaExceededError {
+	quotaErr := &QuotaExceededError{Err: err}
+	match := quotaURIRegex.FindStringSubmatch(uri)
+	if match == nil {
+		return quotaErr
+	}
+	quotaErr.QuotaID = match[2]
+	quotaResp := &types.Quota{}
+	getErr := c.api.DoWithHeaders(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceWithFieldsURI, match[1], quotaErr.QuotaID, QuotaLimitFields), headers, nil, quotaResp)
+	if getErr == nil {
+		quotaErr.HardLimit = quotaResp.QuotaContent.HardLimit
+		quotaErr.SoftLimit = quotaResp.QuotaContent.SoftLimit
+	}
+	return quotaErr
+}
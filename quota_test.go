@@ -0,0 +1,51 @@
+package gounity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dell/gounity/api"
+)
+
+func TestQuota(t *testing.T) {
+	ctx = context.Background()
+
+	quotaExceededTest(t)
+}
+
+func quotaExceededTest(t *testing.T) {
+	fmt.Println("Begin - Quota Exceeded Test")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(api.HeaderKeyContentType, api.HeaderValContentTypeJSON)
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, `{"content":{"id":"treequota_1","hardLimit":2048,"softLimit":1024}}`)
+			return
+		}
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"error":{"errorCode":131149829,"httpStatusCode":422,"messages":[{"en-US":"The quota limit is exceeded."}]}}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(ctx, server.URL, true)
+	if err != nil {
+		t.Fatalf("Create client failed: %v", err)
+	}
+	err = client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityAPIGetResourceURI, api.TreeQuotaAction, "treequota_1")+"/action/modify", map[string]uint64{"hardLimit": 1}, nil)
+	var quotaErr *QuotaExceededError
+	if !errors.Is(err, ErrQuotaExceeded) || !errors.As(err, &quotaErr) || quotaErr.QuotaID != "treequota_1" || quotaErr.HardLimit != 2048 || quotaErr.SoftLimit != 1024 {
+		t.Fatalf("Quota exceeded error not returned: %v", err)
+	}
+
+	//The quota is unknown when the request is not made on the quota
+	err = client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityAPIGetResourceURI, api.PoolAction, "pool_1")+"/action/modify", nil, nil)
+	if !errors.Is(err, ErrQuotaExceeded) || !errors.As(err, &quotaErr) || quotaErr.QuotaID != "" {
+		t.Fatalf("Quota exceeded error of a request on another resource failed: %v", err)
+	}
+
+	fmt.Println("Quota Exceeded Test - Successful")
+}
@@ -0,0 +1,200 @@
+/*
+Copyright (c) 2019 Dell EMC Corporation
+All Rights Reserved
+*/
+
+package gounity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dell/gounity/api"
+	"github.com/dell/gounity/types"
+)
+
+//ErrorReplicationSessionNotFound stores error for replication session not found
+var ErrorReplicationSessionNotFound = errors.New("Unable to find replication session")
+
+//ReplicationSessionNotFoundErrorCode stores error code for replication session not found
+var ReplicationSessionNotFoundErrorCode = "0x7d13005"
+
+//ErrReplicationSessionPaused stores error for a replication session paused while waiting for it's synchronization
+var ErrReplicationSessionPaused = errors.New("replication session is paused")
+
+//ErrReplicationSessionFailed stores error for a replication session in error or without communication with the remote system
+var ErrReplicationSessionFailed = errors.New("replication session failed")
+
+//ErrReplicationStillSyncing stores error for a replication session not synchronized when the wait is cancelled
+var ErrReplicationStillSyncing = errors.New("replication session is still synchronizing")
+
+//Replication session sync state constants
+const (
+	ReplicationSyncStateIdle       = 2 //Asynchronous session with no transfer in progress
+	ReplicationSyncStateOutOfSync  = 4
+	ReplicationSyncStateInSync     = 5 //Synchronous session in sync
+	ReplicationSyncStateConsistent = 6 //Synchronous session consistent
+	ReplicationSyncStateSyncing    = 7
+)
+
+//Replication session status constants
+const (
+	ReplicationStatusNonRecoverableError = 0x0007
+	ReplicationStatusLostCommunication   = 0x000D
+	ReplicationStatusPaused              = 0x8403
+)
+
+//Replication session network status constants
+const (
+	ReplicationNetworkStatusLostCommunication     = 2
+	ReplicationNetworkStatusLostSyncCommunication = 3
+)
+
+//Replication structure
+type Replication struct {
+	client *Client
+}
+
+//NewReplication returns replication
+func NewReplication(client *Client) *Replication {
+	return &Replication{client}
+}
+
+//FindRemoteSystemByID - Find the remote system (replication peer array) by it's Id
+func (r *Replication) FindRemoteSystemByID(ctx context.Context, remoteSystemID string) (*types.RemoteSystem, error) {
+	if len(remoteSystemID) == 0 {
+		return nil, errors.New("remote system Id shouldn't be empty")
+	}
+	remoteSystemResp := &types.RemoteSystem{}
+	err := r.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceWithFieldsURI, api.RemoteSystemAction, remoteSystemID, RemoteSystemDisplayFields), nil, remoteSystemResp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find remote system: %s. Error: %v", remoteSystemID, err)
+	}
+	return remoteSystemResp, nil
+}
+
+//FindReplicationSessionByID - Find the replication session by it's Id
+func (r *Replication) FindReplicationSessionByID(ctx context.Context, sessionID string) (*types.ReplicationSession, error) {
+	if len(sessionID) == 0 {
+		return nil, errors.New("replication session Id shouldn't be empty")
+	}
+	sessionResp := &types.ReplicationSession{}
+	err := r.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceWithFieldsURI, api.ReplicationSessionAction, sessionID, ReplicationSessionDisplayFields), nil, sessionResp)
+	if err != nil {
+		if strings.Contains(err.Error(), ReplicationSessionNotFoundErrorCode) {
+			return nil, ErrorReplicationSessionNotFound
+		}
+		return nil, fmt.Errorf("unable to find replication session: %s. Error: %v", sessionID, err)
+	}
+	return sessionResp, nil
+}
+
+//ListReplicationSessionsBySourceResource - List the replication sessions of the given source storage resource
+func (r *Replication) ListReplicationSessionsBySourceResource(ctx context.Context, storageResourceID string) ([]types.ReplicationSession, error) {
+	if len(storageResourceID) == 0 {
+		return nil, errors.New("storage resource Id shouldn't be empty")
+	}
+	filter := fmt.Sprintf("srcResourceId eq \"%s\"", storageResourceID)
+	sessionsURI := fmt.Sprintf(api.UnityInstancesFilter+"&fields=%s", api.ReplicationSessionAction, url.QueryEscape(filter), ReplicationSessionDisplayFields)
+
+	sessionsResp := &types.ListReplicationSessions{}
+	err := r.client.executeWithRetryAuthenticate(ctx, http.MethodGet, sessionsURI, nil, sessionsResp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list replication sessions of %s. Error: %v", storageResourceID, err)
+	}
+	return sessionsResp.Sessions, nil
+}
+
+//SyncReplicationSession - Trigger a synchronization of the replication session
+func (r *Replication) SyncReplicationSession(ctx context.Context, sessionID string) error {
+	if len(sessionID) == 0 {
+		return errors.New("replication session Id shouldn't be empty")
+	}
+	err := r.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnitySyncReplicationSessionURI, sessionID), nil, nil)
+	if err != nil {
+		return fmt.Errorf("sync replication session: %s failed. Error: %v", sessionID, err)
+	}
+	return nil
+}
+
+//SyncSnapshotReplicationSession - Trigger a synchronization of the replication session from the storage resource of the
+//snapshot to the given remote system and return the session. The whole session is synchronized, not only the snapshot:
+//the snapshot reaches the remote system only if it is replicated by the session (Ex: created with replication enabled).
+//A replication session to the remote system should already exist for the storage resource.
+func (r *Replication) SyncSnapshotReplicationSession(ctx context.Context, snapshotID, remoteSystemID string) (*types.ReplicationSession, error) {
+	log := r.client.getLogger(ctx)
+	if len(snapshotID) == 0 {
+		return nil, errors.New("snapshot Id shouldn't be empty")
+	}
+	if len(remoteSystemID) == 0 {
+		return nil, errors.New("remote system Id shouldn't be empty")
+	}
+
+	snapshot, err := NewSnapshot(r.client).FindSnapshotByID(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = r.FindRemoteSystemByID(ctx, remoteSystemID); err != nil {
+		return nil, err
+	}
+
+	storageResourceID := snapshot.SnapshotContent.StorageResource.ID
+	sessions, err := r.ListReplicationSessionsBySourceResource(ctx, storageResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, session := range sessions {
+		if session.ReplicationSessionContent.RemoteSystem.ID != remoteSystemID {
+			continue
+		}
+		sessionID := session.ReplicationSessionContent.ID
+		if err = r.SyncReplicationSession(ctx, sessionID); err != nil {
+			return nil, err
+		}
+		log.Debugf("Sync of replication session: %s of snapshot: %s to remote system: %s started", sessionID, snapshotID, remoteSystemID)
+		return r.FindReplicationSessionByID(ctx, sessionID)
+	}
+
+	return nil, fmt.Errorf("no replication session found from storage resource %s of snapshot %s to remote system %s", storageResourceID, snapshotID, remoteSystemID)
+}
+
+//WaitForReplicationSync - Wait until the replication session is synchronized (Ex: the initial synchronization after the
+//session is created), polling it at the given interval. ErrReplicationSessionPaused or ErrReplicationSessionFailed are
+//returned as soon as the session is paused or failed, and ErrReplicationStillSyncing when the context ends before.
+func (r *Replication) WaitForReplicationSync(ctx context.Context, sessionID string, pollInterval time.Duration) error {
+	log := r.client.getLogger(ctx)
+	if pollInterval <= 0 {
+		return fmt.Errorf("invalid poll interval: %v", pollInterval)
+	}
+
+	for {
+		session, err := r.FindReplicationSessionByID(ctx, sessionID)
+		if err != nil {
+			return err
+		}
+		content := session.ReplicationSessionContent
+		switch {
+		case content.Status == ReplicationStatusPaused:
+			return fmt.Errorf("%w: %s", ErrReplicationSessionPaused, sessionID)
+		case content.Status == ReplicationStatusNonRecoverableError || content.Status == ReplicationStatusLostCommunication ||
+			content.NetworkStatus == ReplicationNetworkStatusLostCommunication || content.NetworkStatus == ReplicationNetworkStatusLostSyncCommunication:
+			return fmt.Errorf("%w: %s status: %d network status: %d", ErrReplicationSessionFailed, sessionID, content.Status, content.NetworkStatus)
+		case content.SyncState == ReplicationSyncStateIdle || content.SyncState == ReplicationSyncStateInSync || content.SyncState == ReplicationSyncStateConsistent:
+			log.Debugf("Replication session %s is synchronized", sessionID)
+			return nil
+		}
+		log.Debugf("Replication session %s sync state: %d progress: %d%%", sessionID, content.SyncState, content.SyncProgress)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %s progress: %d%%. Error: %v", ErrReplicationStillSyncing, sessionID, content.SyncProgress, ctx.Err())
+		case <-time.After(pollInterval):
+		}
+	}
+}
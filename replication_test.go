@@ -0,0 +1,130 @@
+package gounity
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dell/gounity/api"
+)
+
+func TestReplication(t *testing.T) {
+	requireArray(t)
+	ctx = context.Background()
+
+	findReplicationSessionTest(t)
+	syncSnapshotReplicationSessionTest(t)
+	waitForReplicationSyncTest(t)
+}
+
+func TestReplicationOffline(t *testing.T) {
+	ctx = context.Background()
+
+	syncSnapshotReplicationSessionOfflineTest(t)
+}
+
+func syncSnapshotReplicationSessionOfflineTest(t *testing.T) {
+	fmt.Println("Begin - Sync Snapshot Replication Session Offline Test")
+
+	var synced []string
+	client, server := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(api.HeaderKeyContentType, api.HeaderValContentTypeJSON)
+		switch r.URL.Path {
+		case fmt.Sprintf(api.UnityAPIGetResourceURI, api.SnapAction, "snap_1"):
+			fmt.Fprint(w, `{"content":{"id":"snap_1","name":"backup-snap","storageResource":{"id":"res_1"}}}`)
+		case fmt.Sprintf(api.UnityAPIGetResourceURI, api.RemoteSystemAction, "RS_1"):
+			fmt.Fprint(w, `{"content":{"id":"RS_1","name":"dr-site"}}`)
+		case fmt.Sprintf(api.UnityAPIInstanceTypeResources, api.ReplicationSessionAction):
+			fmt.Fprint(w, `{"entries":[{"content":{"id":"session_1","srcResourceId":"res_1","remoteSystem":{"id":"RS_2"}}},
+				{"content":{"id":"session_2","srcResourceId":"res_1","remoteSystem":{"id":"RS_1"}}}]}`)
+		case fmt.Sprintf(api.UnitySyncReplicationSessionURI, "session_1"), fmt.Sprintf(api.UnitySyncReplicationSessionURI, "session_2"):
+			synced = append(synced, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		case fmt.Sprintf(api.UnityAPIGetResourceURI, api.ReplicationSessionAction, "session_2"):
+			fmt.Fprintf(w, `{"content":{"id":"session_2","srcResourceId":"res_1","remoteSystem":{"id":"RS_1"},"syncState":%d}}`, ReplicationSyncStateSyncing)
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	session, err := NewReplication(client).SyncSnapshotReplicationSession(ctx, "snap_1", "RS_1")
+	if err != nil {
+		t.Fatalf("Sync snapshot replication session failed: %v", err)
+	}
+	if session.ReplicationSessionContent.ID != "session_2" {
+		t.Fatalf("Sync snapshot replication session returned an unexpected session: %+v", session.ReplicationSessionContent)
+	}
+	if len(synced) != 1 || synced[0] != fmt.Sprintf(api.UnitySyncReplicationSessionURI, "session_2") {
+		t.Fatalf("Sync snapshot replication session synchronized unexpected sessions: %v", synced)
+	}
+
+	fmt.Println("Sync Snapshot Replication Session Offline Test Successful")
+}
+
+func findReplicationSessionTest(t *testing.T) {
+
+	fmt.Println("Begin - Find Replication Session Test")
+
+	//Negative cases
+	_, err := testConf.replicationAPI.FindReplicationSessionByID(ctx, "")
+	if err == nil {
+		t.Fatalf("Find replication session with empty Id case - failed: %v", err)
+	}
+
+	_, err = testConf.replicationAPI.FindReplicationSessionByID(ctx, "dummy_session_1")
+	if err == nil {
+		t.Fatalf("Find replication session with invalid Id case - failed: %v", err)
+	}
+
+	_, err = testConf.replicationAPI.FindRemoteSystemByID(ctx, "dummy_remote_system_1")
+	if err == nil {
+		t.Fatalf("Find remote system with invalid Id case - failed: %v", err)
+	}
+
+	fmt.Println("Find Replication Session Test - Successful")
+}
+
+func syncSnapshotReplicationSessionTest(t *testing.T) {
+
+	fmt.Println("Begin - Sync Snapshot Replication Session Test")
+
+	//Negative cases
+	_, err := testConf.replicationAPI.SyncSnapshotReplicationSession(ctx, "", "RS_1")
+	if err == nil {
+		t.Fatalf("Sync snapshot replication session with empty snapshot Id case - failed: %v", err)
+	}
+
+	_, err = testConf.replicationAPI.SyncSnapshotReplicationSession(ctx, "dummy_snap_1", "")
+	if err == nil {
+		t.Fatalf("Sync snapshot replication session with empty remote system Id case - failed: %v", err)
+	}
+
+	_, err = testConf.replicationAPI.SyncSnapshotReplicationSession(ctx, "dummy_snap_1", "RS_1")
+	if err == nil {
+		t.Fatalf("Sync snapshot replication session with invalid snapshot Id case - failed: %v", err)
+	}
+
+	fmt.Println("Sync Snapshot Replication Session Test - Successful")
+}
+
+func waitForReplicationSyncTest(t *testing.T) {
+
+	fmt.Println("Begin - Wait For Replication Sync Test")
+
+	//Negative cases
+	err := testConf.replicationAPI.WaitForReplicationSync(ctx, "dummy_session_1", 0)
+	if err == nil {
+		t.Fatalf("Wait for replication sync with invalid poll interval case - failed: %v", err)
+	}
+
+	err = testConf.replicationAPI.WaitForReplicationSync(ctx, "dummy_session_1", time.Second)
+	if err != ErrorReplicationSessionNotFound {
+		t.Fatalf("Wait for replication sync with invalid Id case - failed: %v", err)
+	}
+
+	fmt.Println("Wait For Replication Sync Test - Successful")
+}
@@ -0,0 +1,36 @@
+package gounity
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+//ErrRetryBudgetExhausted is returned when a request fails and the retry budget of it's context is exhausted
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+
+//retryBudgetKey is the context key of the retry budget
+type retryBudgetKey struct{}
+
+//retryBudget is the number of retries left, shared by all the requests made with the context
+type retryBudget struct {
+	remaining int64
+}
+
+//WithRetryBudget returns a context allowing at most n retries in total across all the requests made with it (Ex: by
+//a single reconcile), instead of the retries of each request. Once the budget is spent, a request failing with an
+//error which would otherwise be retried (Ex: an expired session) fails immediately with ErrRetryBudgetExhausted.
+//Errors never retried, like ErrArrayInMaintenance, don't use the budget.
+func WithRetryBudget(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, retryBudgetKey{}, &retryBudget{remaining: int64(n)})
+}
+
+//takeRetry uses one retry of the budget of the context, it returns false if the budget is exhausted.
+//A context without a retry budget always allows the retry.
+func takeRetry(ctx context.Context) bool {
+	budget, ok := ctx.Value(retryBudgetKey{}).(*retryBudget)
+	if !ok {
+		return true
+	}
+	return atomic.AddInt64(&budget.remaining, -1) >= 0
+}
@@ -0,0 +1,65 @@
+package gounity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dell/gounity/api"
+)
+
+func TestRetryBudget(t *testing.T) {
+	ctx = context.Background()
+
+	retryBudgetTest(t)
+}
+
+func retryBudgetTest(t *testing.T) {
+	fmt.Println("Begin - Retry Budget Test")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(api.HeaderKeyContentType, api.HeaderValContentTypeJSON)
+		if r.URL.Path == api.UnityAPILoginSessionInfoURI {
+			http.SetCookie(w, &http.Cookie{Name: "mod_sec_emc", Value: "session-1", Path: "/"})
+			w.Header().Set(emcCsrfToken, "token-1")
+			fmt.Fprint(w, `{"content":{"id":"user_1"}}`)
+			return
+		}
+		//The session expired when the session cookie is missing
+		if _, err := r.Cookie("mod_sec_emc"); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":{"errorCode":131044876,"httpStatusCode":401,"messages":[{"en-US":"Unauthorized"}]}}`)
+			return
+		}
+		fmt.Fprint(w, `{"content":{"id":"pool_1"}}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(ctx, server.URL, true)
+	if err != nil {
+		t.Fatalf("Create client failed: %v", err)
+	}
+	err = client.Authenticate(ctx, &ConfigConnect{Endpoint: server.URL, Username: "user", Password: "password"})
+	if err != nil {
+		t.Fatalf("Authenticate failed: %v", err)
+	}
+	poolAPI := NewStoragePool(client)
+
+	client.api.ClearSession()
+	_, err = poolAPI.FindStoragePoolByID(WithRetryBudget(ctx, 1), "pool_1")
+	if err != nil {
+		t.Fatalf("Find Pool by Id with an expired session and a retry budget failed: %v", err)
+	}
+
+	//Negative case
+	client.api.ClearSession()
+	_, err = poolAPI.FindStoragePoolByID(WithRetryBudget(ctx, 0), "pool_1")
+	if !errors.Is(err, ErrRetryBudgetExhausted) {
+		t.Fatalf("Find Pool by Id with an expired session and no retry budget case - failed: %v", err)
+	}
+
+	fmt.Println("Retry Budget Test Successful")
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/dell/gounity/api"
 	"github.com/dell/gounity/types"
@@ -28,6 +29,26 @@ var SnapshotNotFoundErrorCode = "0x7d13005"
 //ErrorSnapshotNotFound stores Snapshot not found error
 var ErrorSnapshotNotFound = errors.New("Unable to find filesystem")
 
+//Snapshot states
+const (
+	SnapshotStateReady        = 2
+	SnapshotStateFaulted      = 3
+	SnapshotStateOffline      = 6
+	SnapshotStateInvalid      = 7
+	SnapshotStateInitializing = 8
+	SnapshotStateDestroying   = 9
+)
+
+//ErrCannotSnapshotSnapshot stores error for a snapshot requested of a snapshot instead of a storage resource, use
+//CopySnapshot to copy a snapshot
+var ErrCannotSnapshotSnapshot = errors.New("cannot take a snapshot of a snapshot, copy the snapshot instead")
+
+//ErrSnapshotFailed stores error for a snapshot faulted, offline, invalid or being destroyed while waiting for it to be ready
+var ErrSnapshotFailed = errors.New("snapshot failed")
+
+//ErrSnapshotNotReady stores error for a snapshot not ready when the wait is cancelled
+var ErrSnapshotNotReady = errors.New("snapshot is not ready")
+
 //Snapshot structure
 type Snapshot struct {
 	client *Client
@@ -52,7 +73,8 @@ func (s *Snapshot) CreateSnapshot(ctx context.Context, storageResourceID, snapsh
 	return s.CreateSnapshotWithFsAccesType(ctx, storageResourceID, snapshotName, description, retentionDuration, BlockAccessType)
 }
 
-//CreateSnapshotWithFsAccesType - Creates snashot with FsAccess type
+//CreateSnapshotWithFsAccesType - Creates snashot with FsAccess type. ErrCannotSnapshotSnapshot is returned if the
+//storage resource Id is the Id of a snapshot.
 func (s *Snapshot) CreateSnapshotWithFsAccesType(ctx context.Context, storageResourceID, snapshotName, description, retentionDuration string, filesystemAccessType FilesystemAccessType) (*types.Snapshot, error) {
 	var createSnapshot types.CreateSnapshotParam
 	if len(storageResourceID) == 0 {
@@ -82,15 +104,67 @@ func (s *Snapshot) CreateSnapshotWithFsAccesType(ctx context.Context, storageRes
 
 	snapshotResp := &types.Snapshot{}
 	err = s.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityAPIInstanceTypeResources, api.SnapAction), createSnapshot, snapshotResp)
+	if isResponseLost(err) {
+		existing, findErr := s.FindSnapshotByName(ctx, createSnapshot.Name)
+		if findErr == nil && existing.SnapshotContent.StorageResource.ID == storageResourceID {
+			log := s.client.getLogger(ctx)
+			log.Warnf("Create snapshot %s response lost, snapshot %s found created. Error: %v", createSnapshot.Name, existing.SnapshotContent.ResourceID, err)
+			return existing, nil
+		}
+	}
 	if err != nil {
+		//The array rejects the snapshot of a snapshot as an unknown storage resource
+		if _, findErr := s.FindSnapshotByID(ctx, storageResourceID); findErr == nil {
+			return nil, fmt.Errorf("create snapshot %s of %s failed: %w", createSnapshot.Name, storageResourceID, ErrCannotSnapshotSnapshot)
+		}
 		return nil, err
 	}
 	return snapshotResp, nil
 }
 
+//SnapshotConsistencyGroup - Create a snapshot of the consistency group with the given storage resource Id. The array
+//creates a group snapshot and a member snapshot per LUN of the group, the group snapshot is returned with the Ids of the
+//member snapshots, which can be referenced individually (Ex: to restore or thin clone a single LUN).
+func (s *Snapshot) SnapshotConsistencyGroup(ctx context.Context, groupID, snapshotName, description, retentionDuration string) (*types.Snapshot, []string, error) {
+	storageResource, err := s.client.FindStorageResourceByID(ctx, groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if storageResource.StorageResourceContent.Type != StorageResourceTypeConsistencyGroup {
+		return nil, nil, fmt.Errorf("storage resource %s is not a consistency group", groupID)
+	}
+
+	groupSnap, err := s.CreateSnapshot(ctx, groupID, snapshotName, description, retentionDuration)
+	if err != nil {
+		return nil, nil, err
+	}
+	members, err := s.ListConsistencyGroupSnapshotMembers(ctx, groupSnap.SnapshotContent.ResourceID)
+	if err != nil {
+		return groupSnap, nil, err
+	}
+	memberIDs := []string{}
+	for _, member := range members {
+		memberIDs = append(memberIDs, member.SnapshotContent.ResourceID)
+	}
+	return groupSnap, memberIDs, nil
+}
+
+//ListConsistencyGroupSnapshotMembers - List the member snapshots (one per LUN of the group) of a consistency group snapshot
+func (s *Snapshot) ListConsistencyGroupSnapshotMembers(ctx context.Context, groupSnapID string) ([]types.Snapshot, error) {
+	if len(groupSnapID) == 0 {
+		return nil, errors.New("group snapshot Id cannot be empty")
+	}
+	snapshotsResp := &types.ListSnapshot{}
+	err := s.client.QueryInstances(ctx, api.SnapAction, strings.Split(SnapshotDisplayFields, ","), fmt.Sprintf("snapGroup.id eq \"%s\"", groupSnapID), snapshotsResp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list member snapshots of group snapshot: %s. Error: %v", groupSnapID, err)
+	}
+	return snapshotsResp.Snapshots, nil
+}
+
 //DeleteFilesystemAsSnapshot - Delete Snapshots acting as filesystem on array
 func (s *Snapshot) DeleteFilesystemAsSnapshot(ctx context.Context, snapshotID string, sourceFs *types.Filesystem) error {
-	log := util.GetRunIDLogger(ctx)
+	log := s.client.getLogger(ctx)
 	deleteSourceFs := false
 	if strings.Contains(sourceFs.FileContent.Description, MarkFilesystemForDeletion) {
 		deleteSourceFs = true
@@ -103,7 +177,9 @@ func (s *Snapshot) DeleteFilesystemAsSnapshot(ctx context.Context, snapshotID st
 		//Try deleting the marked filesystem for deletion
 		f := NewFilesystem(s.client)
 		err = f.DeleteFilesystem(ctx, sourceFs.FileContent.ID)
-		if err != nil {
+		if errors.Is(err, ErrFilesystemMarkedForDeletion) {
+			log.Debugf("Source filesystem: %s marked for deletion still has snapshots", sourceFs.FileContent.ID)
+		} else if err != nil {
 			log.Warnf("Deletion of source filesystem: %s marked for deletion failed with error: %v", sourceFs.FileContent.ID, err)
 		}
 	}
@@ -119,7 +195,7 @@ func (s *Snapshot) DeleteFilesystemAsSnapshot(ctx context.Context, snapshotID st
 // Returns:
 // - an error if delete snapshot fails
 func (s *Snapshot) DeleteSnapshot(ctx context.Context, snapshotID string) error {
-	log := util.GetRunIDLogger(ctx)
+	log := s.client.getLogger(ctx)
 	if snapshotID == "" {
 		return errors.New("snapshot ID cannot be empty")
 	}
@@ -179,7 +255,7 @@ func (s *Snapshot) ListSnapshots(ctx context.Context, startToken int, maxEntries
 
 //FindSnapshotByName - To find snapshot using snapshot-name
 func (s *Snapshot) FindSnapshotByName(ctx context.Context, snapshotName string) (*types.Snapshot, error) {
-	log := util.GetRunIDLogger(ctx)
+	log := s.client.getLogger(ctx)
 	snapshotName, err := util.ValidateResourceName(snapshotName, api.MaxResourceNameLength)
 	if err != nil {
 		return nil, err
@@ -198,7 +274,7 @@ func (s *Snapshot) FindSnapshotByName(ctx context.Context, snapshotName string)
 
 //FindSnapshotByID - To find snapshot using snapshot-id
 func (s *Snapshot) FindSnapshotByID(ctx context.Context, snapshotID string) (*types.Snapshot, error) {
-	log := util.GetRunIDLogger(ctx)
+	log := s.client.getLogger(ctx)
 	if snapshotID == "" {
 		return nil, errors.New("snapshot ID cannot be empty")
 	}
@@ -214,9 +290,41 @@ func (s *Snapshot) FindSnapshotByID(ctx context.Context, snapshotID string) (*ty
 	return snapshotResp, nil
 }
 
+//WaitForSnapshotReady - Wait until the snapshot is ready to be used (Ex: to create a NFS Share from it), polling it at
+//the given interval. ErrSnapshotFailed is returned as soon as the snapshot is in a failed state, and ErrSnapshotNotReady
+//when the context ends before.
+func (s *Snapshot) WaitForSnapshotReady(ctx context.Context, snapshotID string, pollInterval time.Duration) error {
+	log := s.client.getLogger(ctx)
+	if pollInterval <= 0 {
+		return fmt.Errorf("invalid poll interval: %v", pollInterval)
+	}
+
+	for {
+		snapshot, err := s.FindSnapshotByID(ctx, snapshotID)
+		if err != nil {
+			return err
+		}
+		state := snapshot.SnapshotContent.State
+		switch state {
+		case SnapshotStateReady:
+			log.Debugf("Snapshot %s is ready", snapshotID)
+			return nil
+		case SnapshotStateFaulted, SnapshotStateOffline, SnapshotStateInvalid, SnapshotStateDestroying:
+			return fmt.Errorf("%w: %s state: %d", ErrSnapshotFailed, snapshotID, state)
+		}
+		log.Debugf("Snapshot %s state: %d", snapshotID, state)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %s state: %d. Error: %v", ErrSnapshotNotReady, snapshotID, state, ctx.Err())
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
 //ModifySnapshotAutoDeleteParameter - Modify Snapshot (currently used to disable auto-delete parameter)
 func (s *Snapshot) ModifySnapshotAutoDeleteParameter(ctx context.Context, snapshotID string) error {
-	log := util.GetRunIDLogger(ctx)
+	log := s.client.getLogger(ctx)
 	if snapshotID == "" {
 		return errors.New("snapshot ID cannot be empty")
 	}
@@ -290,3 +398,78 @@ func (s *Snapshot) ModifySnapshot(ctx context.Context, snapshotID, description,
 	}
 	return nil
 }
+
+//ListOrphanedSnapshots - List the snapshots created before olderThan that nothing depends on: no NFS share exports them,
+//no host is attached to them, they are not a member of a snapshot group (consistency group snapshot), no other snapshot
+//is created from them and their storage resource is not the source of a replication session. The snapshots are checked
+//a page of maxEntries snapshots (DefaultListPageSize if 0) at a time, the returned token, empty on the last page, is
+//passed back as nextToken to check the next page; a page may hold fewer snapshots than maxEntries, even none, while the
+//returned token is not empty. Meant for space reclamation, the snapshots returned can be deleted with DeleteSnapshots.
+func (s *Snapshot) ListOrphanedSnapshots(ctx context.Context, olderThan time.Duration, nextToken string, maxEntries int) ([]types.Snapshot, string, error) {
+	snapshotsResp := &types.ListSnapshot{}
+	token, err := s.client.listPage(ctx, api.SnapAction, strings.Split(SnapshotDisplayFields, ","), "", nextToken, maxEntries, snapshotsResp)
+	if err != nil {
+		return nil, "", err
+	}
+
+	inUse := make(map[string]bool)
+	sharesResp := &types.ListNFSShares{}
+	err = s.client.QueryInstances(ctx, api.NfsShareAction, []string{"id", "snap"}, "", sharesResp)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, share := range sharesResp.NFSShares {
+		if share.NFSShareContent.Snapshot.ID != "" {
+			inUse[share.NFSShareContent.Snapshot.ID] = true
+		}
+	}
+	//the snapshots created from a snapshot of the page may be on any page
+	parentsResp := &types.ListSnapshot{}
+	err = s.client.QueryInstances(ctx, api.SnapAction, []string{"id", "parentSnap"}, "", parentsResp)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, snapshot := range parentsResp.Snapshots {
+		if snapshot.SnapshotContent.ParentSnap.ID != "" {
+			inUse[snapshot.SnapshotContent.ParentSnap.ID] = true
+		}
+	}
+
+	replicated := make(map[string]bool)
+	sessionsResp := &types.ListReplicationSessions{}
+	err = s.client.QueryInstances(ctx, api.ReplicationSessionAction, []string{"id", "srcResourceId"}, "", sessionsResp)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, session := range sessionsResp.Sessions {
+		replicated[session.ReplicationSessionContent.SrcResourceID] = true
+	}
+
+	orphaned := []types.Snapshot{}
+	for _, snapshot := range snapshotsResp.Snapshots {
+		content := snapshot.SnapshotContent
+		if time.Since(content.CreationTime) < olderThan || inUse[content.ResourceID] || replicated[content.StorageResource.ID] {
+			continue
+		}
+		if len(content.HostAccess) > 0 || content.SnapGroup.ID != "" {
+			continue
+		}
+		orphaned = append(orphaned, snapshot)
+	}
+	return orphaned, token, nil
+}
+
+//DeleteSnapshots - Delete the given snapshots. A failure doesn't stop the deletion of the remaining snapshots,
+//the failures are reported together in the returned error.
+func (s *Snapshot) DeleteSnapshots(ctx context.Context, snapshotIDs []string) error {
+	var failures []string
+	for _, snapshotID := range snapshotIDs {
+		if err := s.DeleteSnapshot(ctx, snapshotID); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("unable to delete %d of %d snapshots: %s", len(failures), len(snapshotIDs), strings.Join(failures, "; "))
+	}
+	return nil
+}
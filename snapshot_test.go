@@ -2,9 +2,13 @@ package gounity
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
+
+	"github.com/dell/gounity/api"
 )
 
 var snapVolName string
@@ -17,6 +21,7 @@ var cloneVolName string
 var cloneVolID string
 
 func TestSnapshot(t *testing.T) {
+	requireArray(t)
 
 	now := time.Now()
 	timeStamp := now.Format("20060102150405")
@@ -35,6 +40,47 @@ func TestSnapshot(t *testing.T) {
 	deleteSnapshot(t)
 }
 
+func TestSnapshotOffline(t *testing.T) {
+	ctx = context.Background()
+
+	listOrphanedSnapshotsOfflineTest(t)
+}
+
+func listOrphanedSnapshotsOfflineTest(t *testing.T) {
+	fmt.Println("Begin - List Orphaned Snapshots Offline Test")
+
+	created := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+	client, server := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(api.HeaderKeyContentType, api.HeaderValContentTypeJSON)
+		switch r.URL.Path {
+		case fmt.Sprintf(api.UnityAPIInstanceTypeResources, api.SnapAction):
+			fmt.Fprintf(w, `{"entries":[
+				{"content":{"id":"snap_1","name":"orphaned","storageResource":{"id":"res_1"},"creationTime":"%[1]s"}},
+				{"content":{"id":"snap_2","name":"attached","storageResource":{"id":"res_1"},"creationTime":"%[1]s","hostAccess":[{"host":{"id":"Host_1"},"allowedAccess":1}]}},
+				{"content":{"id":"snap_3","name":"group-member","storageResource":{"id":"res_2"},"creationTime":"%[1]s","snapGroup":{"id":"snap_10"}}},
+				{"content":{"id":"snap_4","name":"exported","storageResource":{"id":"res_1"},"creationTime":"%[1]s"}},
+				{"content":{"id":"snap_5","name":"replicated","storageResource":{"id":"res_3"},"creationTime":"%[1]s"}}]}`, created)
+		case fmt.Sprintf(api.UnityAPIInstanceTypeResources, api.NfsShareAction):
+			fmt.Fprint(w, `{"entries":[{"content":{"id":"NFSShare_1","snap":{"id":"snap_4"}}}]}`)
+		case fmt.Sprintf(api.UnityAPIInstanceTypeResources, api.ReplicationSessionAction):
+			fmt.Fprint(w, `{"entries":[{"content":{"id":"session_1","srcResourceId":"res_3"}}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	orphaned, token, err := NewSnapshot(client).ListOrphanedSnapshots(ctx, time.Hour, "", 0)
+	if err != nil || token != "" {
+		t.Fatalf("List orphaned snapshots failed: %s %v", token, err)
+	}
+	if len(orphaned) != 1 || orphaned[0].SnapshotContent.ResourceID != "snap_1" {
+		t.Fatalf("List orphaned snapshots returned snapshots in use: %+v", orphaned)
+	}
+
+	fmt.Println("List Orphaned Snapshots Offline Test Successful")
+}
+
 func createSnapshotTest(t *testing.T) {
 
 	fmt.Println("Begin - Create Snapshot Test")
@@ -85,6 +131,26 @@ func createSnapshotTest(t *testing.T) {
 		t.Fatalf("Create duplicate Snapshot case failed: %v", err)
 	}
 
+	_, err = testConf.snapAPI.CreateSnapshot(ctx, snap.SnapshotContent.ResourceID, snap2Name+"-snap", "Snapshot Description", "")
+	if !errors.Is(err, ErrCannotSnapshotSnapshot) {
+		t.Fatalf("Create Snapshot of a snapshot case failed: %v", err)
+	}
+
+	members, err := testConf.snapAPI.ListConsistencyGroupSnapshotMembers(ctx, snap.SnapshotContent.ResourceID)
+	if err != nil || len(members) != 0 {
+		t.Fatalf("List member snapshots of a LUN snapshot case failed: %v %v", members, err)
+	}
+
+	_, _, err = testConf.snapAPI.SnapshotConsistencyGroup(ctx, snapVolID, snap2Name+"-cg", "Snapshot Description", "")
+	if err == nil {
+		t.Fatalf("Snapshot consistency group of a LUN case failed: %v", err)
+	}
+
+	_, err = testConf.snapAPI.ListConsistencyGroupSnapshotMembers(ctx, "")
+	if err == nil {
+		t.Fatalf("List member snapshots with empty group snapshot Id case failed: %v", err)
+	}
+
 	fmt.Println("Create Snapshot Test - Successful")
 }
 
@@ -132,7 +198,17 @@ func findSnapshotByIDTest(t *testing.T) {
 		t.Fatalf("Find snapshot failed: %v", err)
 	}
 
+	err = testConf.snapAPI.WaitForSnapshotReady(ctx, snapID, time.Second)
+	if err != nil {
+		t.Fatalf("Wait for snapshot ready failed: %v", err)
+	}
+
 	//Negative test cases
+	err = testConf.snapAPI.WaitForSnapshotReady(ctx, snapID, 0)
+	if err == nil {
+		t.Fatalf("Wait for snapshot ready with invalid poll interval case failed: %v", err)
+	}
+
 	snapIDTemp := ""
 	_, err = testConf.snapAPI.FindSnapshotByID(ctx, snapIDTemp)
 	if err == nil {
@@ -176,6 +252,23 @@ func listSnapshotsTest(t *testing.T) {
 		t.Fatalf("List snapshot pagination failed: %v", err)
 	}
 
+	orphaned, _, err := testConf.snapAPI.ListOrphanedSnapshots(ctx, time.Hour, "", 0)
+	if err != nil {
+		t.Fatalf("List orphaned snapshots failed: %v", err)
+	}
+	for _, snap := range orphaned {
+		if snap.SnapshotContent.ResourceID == snapID {
+			t.Fatalf("List orphaned snapshots returned the snapshot created now: %s", snapID)
+		}
+	}
+	fmt.Println("List orphaned snapshots:", len(orphaned))
+
+	//Negative case
+	err = testConf.snapAPI.DeleteSnapshots(ctx, []string{"dummy_snap_1"})
+	if err == nil {
+		t.Fatalf("Delete snapshots with invalid Id case - failed: %v", err)
+	}
+
 	fmt.Println("List Snapshots Test - Successful")
 }
 
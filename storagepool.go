@@ -16,6 +16,36 @@ import (
 	"github.com/dell/gounity/types"
 )
 
+//Pool alert threshold limits allowed by the array
+const (
+	MinPoolAlertThreshold = 50
+	MaxPoolAlertThreshold = 84
+)
+
+//PoolHealthMajorFailure stores the health value from which a pool is considered unfit for provisioning
+const PoolHealthMajorFailure = 20
+
+//Tier types of a storage pool tier
+const (
+	TierTypeNone               = 0
+	TierTypeExtremePerformance = 10
+	TierTypePerformance        = 20
+	TierTypeCapacity           = 30
+)
+
+//RAID types of a storage pool tier
+const (
+	RaidTypeNone      = 0
+	RaidType5         = 1
+	RaidType0         = 2
+	RaidType1         = 3
+	RaidType3         = 4
+	RaidType10        = 7
+	RaidType6         = 10
+	RaidTypeMixed     = 12
+	RaidTypeAutomatic = 48879
+)
+
 //Storagepool structure
 type Storagepool struct {
 	client *Client
@@ -27,30 +57,206 @@ func NewStoragePool(client *Client) *Storagepool {
 }
 
 //FindStoragePoolByName - Find the volume by it's name. If the volume is not found, an error will be returned.
-func (sp *Storagepool) FindStoragePoolByName(ctx context.Context, poolName string) (*types.StoragePool, error) {
+//Only the given fields are fetched when any, StoragePoolFields otherwise.
+func (sp *Storagepool) FindStoragePoolByName(ctx context.Context, poolName string, fields ...string) (*types.StoragePool, error) {
 	if len(poolName) == 0 {
 		return nil, errors.New("poolName shouldn't be empty")
 	}
 	spResponse := &types.StoragePool{}
-	err := sp.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceByNameWithFieldsURI, api.PoolAction, poolName, StoragePoolFields), nil, spResponse)
+	err := sp.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceByNameWithFieldsURI, api.PoolAction, poolName, displayFields(StoragePoolFields, fields)), nil, spResponse)
 	if err != nil {
-		return nil, fmt.Errorf("find storage pool by name failed %s err: %v", poolName, err)
+		return nil, fmt.Errorf("find storage pool by name failed %s err: %w", poolName, err)
 	}
 
 	return spResponse, nil
 }
 
 //FindStoragePoolByID - Find the volume by it's Id. If the volume is not found, an error will be returned.
-func (sp *Storagepool) FindStoragePoolByID(ctx context.Context, poolID string) (*types.StoragePool, error) {
+//Only the given fields are fetched when any, StoragePoolFields otherwise.
+func (sp *Storagepool) FindStoragePoolByID(ctx context.Context, poolID string, fields ...string) (*types.StoragePool, error) {
 	if len(poolID) == 0 {
 		return nil, errors.New("pool Id cannot be empty")
 	}
 	spResponse := &types.StoragePool{}
 
-	err := sp.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceWithFieldsURI, api.PoolAction, poolID, StoragePoolFields), nil, spResponse)
+	err := sp.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceWithFieldsURI, api.PoolAction, poolID, displayFields(StoragePoolFields, fields)), nil, spResponse)
 	if err != nil {
-		return nil, fmt.Errorf("find storage pool by ID failed %s err: %v", poolID, err)
+		return nil, fmt.Errorf("find storage pool by ID failed %s err: %w", poolID, err)
 	}
 
 	return spResponse, nil
 }
+
+//GetPoolAlertThresholds - Get the space alert and space harvesting thresholds of the storage pool
+func (sp *Storagepool) GetPoolAlertThresholds(ctx context.Context, poolID string) (*types.PoolThresholds, error) {
+	if len(poolID) == 0 {
+		return nil, errors.New("pool Id cannot be empty")
+	}
+	spResponse := &types.StoragePool{}
+
+	err := sp.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceWithFieldsURI, api.PoolAction, poolID, StoragePoolThresholdFields), nil, spResponse)
+	if err != nil {
+		return nil, fmt.Errorf("get storage pool thresholds failed %s err: %v", poolID, err)
+	}
+
+	return &types.PoolThresholds{
+		AlertThreshold:                spResponse.StoragePoolContent.AlertThreshold,
+		IsHarvestEnabled:              spResponse.StoragePoolContent.IsHarvestEnabled,
+		PoolSpaceHarvestHighThreshold: spResponse.StoragePoolContent.PoolSpaceHarvestHighThreshold,
+		PoolSpaceHarvestLowThreshold:  spResponse.StoragePoolContent.PoolSpaceHarvestLowThreshold,
+	}, nil
+}
+
+//SetPoolAlertThresholds - Set the space usage percentage of the storage pool at which the array raises an alert
+func (sp *Storagepool) SetPoolAlertThresholds(ctx context.Context, poolID string, alertThreshold int) error {
+	log := sp.client.getLogger(ctx)
+	if len(poolID) == 0 {
+		return errors.New("pool Id cannot be empty")
+	}
+	if alertThreshold < MinPoolAlertThreshold || alertThreshold > MaxPoolAlertThreshold {
+		return fmt.Errorf("alert threshold %d should be in between %d-%d", alertThreshold, MinPoolAlertThreshold, MaxPoolAlertThreshold)
+	}
+
+	poolModifyParam := types.StoragePoolModifyParam{
+		AlertThreshold: alertThreshold,
+	}
+	err := sp.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityModifyStoragePoolURI, api.PoolAction, poolID), poolModifyParam, nil)
+	if err != nil {
+		return fmt.Errorf("set storage pool alert threshold failed %s err: %v", poolID, err)
+	}
+	log.Debugf("Set alert threshold of storage pool %s to %d successful", poolID, alertThreshold)
+	return nil
+}
+
+//GetPoolTiers - Get the tiers of the storage pool (Ex: TierTypeExtremePerformance for flash drives) with their RAID type
+//and disk count, to place performance sensitive workloads. Only the tiers having disks are returned.
+func (sp *Storagepool) GetPoolTiers(ctx context.Context, poolID string) ([]types.PoolTier, error) {
+	pool, err := sp.FindStoragePoolByID(ctx, poolID, StoragePoolTierFields)
+	if err != nil {
+		return nil, err
+	}
+	tiers := []types.PoolTier{}
+	for _, tier := range pool.StoragePoolContent.Tiers {
+		if tier.DiskCount > 0 {
+			tiers = append(tiers, tier)
+		}
+	}
+	return tiers, nil
+}
+
+//CanPoolHostFilesystem - Check whether the storage pool can host a new filesystem of the given size.
+//Returns false along with a human readable reason when the pool is not suitable.
+func (sp *Storagepool) CanPoolHostFilesystem(ctx context.Context, poolID string, size uint64, thin bool) (bool, string, error) {
+	pool, err := sp.FindStoragePoolByID(ctx, poolID)
+	if err != nil {
+		return false, "", err
+	}
+	canHost, reason := poolCanHostFilesystem(pool, size, thin)
+	return canHost, reason, nil
+}
+
+//GetPoolUtilization - Get the used capacity of the storage pool as a percentage (0-100) of it's total capacity
+func (sp *Storagepool) GetPoolUtilization(ctx context.Context, poolID string) (float64, error) {
+	pool, err := sp.FindStoragePoolByID(ctx, poolID, "id", "sizeTotal", "sizeUsed")
+	if err != nil {
+		return 0, err
+	}
+	return poolUtilization(pool), nil
+}
+
+//ListPoolsAboveUtilization - List the storage pools whose used capacity percentage is above the given threshold (0-100)
+func (sp *Storagepool) ListPoolsAboveUtilization(ctx context.Context, threshold float64) ([]types.StoragePool, error) {
+	if threshold < 0 || threshold > 100 {
+		return nil, fmt.Errorf("utilization threshold %v should be in between 0-100", threshold)
+	}
+	allPools, err := sp.ListStoragePools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pools := []types.StoragePool{}
+	for _, pool := range allPools {
+		if poolUtilization(&pool) > threshold {
+			pools = append(pools, pool)
+		}
+	}
+	return pools, nil
+}
+
+//ListStoragePools - List all the storage pools of the array with StoragePoolFields
+func (sp *Storagepool) ListStoragePools(ctx context.Context) ([]types.StoragePool, error) {
+	poolsResp := &types.ListStoragePools{}
+	err := sp.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIInstanceTypeResourcesWithFields, api.PoolAction, StoragePoolFields), nil, poolsResp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list storage pools. Error: %v", err)
+	}
+	return poolsResp.StoragePools, nil
+}
+
+//GetArrayCapacitySummary - Get the total, used, free and subscribed capacity summed across all the storage pools of
+//the array. The subscription is the capacity promised to the storage resources (thin ones included), the array is
+//overcommitted when it exceeds the total capacity.
+func (sp *Storagepool) GetArrayCapacitySummary(ctx context.Context) (*types.CapacitySummary, error) {
+	pools, err := sp.ListStoragePools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return capacitySummary(pools), nil
+}
+
+//GetThinSubscription - Get the subscription percentage of the array, the capacity subscribed by the storage resources
+//(thin ones included) summed across all the storage pools over their total capacity. Above 100 the array is
+//overcommitted, 0 is returned for an array without pool capacity.
+func (sp *Storagepool) GetThinSubscription(ctx context.Context) (float64, error) {
+	pools, err := sp.ListStoragePools(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return capacitySummary(pools).SubscriptionPercent, nil
+}
+
+//capacitySummary sums the capacity of the pools and computes the used and subscribed percentages of the total capacity
+func capacitySummary(pools []types.StoragePool) *types.CapacitySummary {
+	summary := &types.CapacitySummary{PoolCount: len(pools)}
+	for _, pool := range pools {
+		content := pool.StoragePoolContent
+		summary.SizeTotal += content.TotalCapacity
+		summary.SizeUsed += content.UsedCapacity
+		summary.SizeFree += content.FreeCapacity
+		summary.SizeSubscribed += content.SubscribedCapacity
+	}
+	if summary.SizeTotal == 0 {
+		return summary
+	}
+	summary.UsedPercent = float64(summary.SizeUsed) / float64(summary.SizeTotal) * 100
+	summary.SubscriptionPercent = float64(summary.SizeSubscribed) / float64(summary.SizeTotal) * 100
+	if summary.SizeSubscribed > summary.SizeTotal {
+		summary.IsOvercommitted = true
+		summary.SizeOvercommitted = summary.SizeSubscribed - summary.SizeTotal
+	}
+	return summary
+}
+
+//poolUtilization returns the used capacity percentage of the pool, 0 for a pool without capacity
+func poolUtilization(pool *types.StoragePool) float64 {
+	content := pool.StoragePoolContent
+	if content.TotalCapacity == 0 {
+		return 0
+	}
+	return float64(content.UsedCapacity) / float64(content.TotalCapacity) * 100
+}
+
+//poolCanHostFilesystem validates health and free capacity of the pool for a new filesystem of the given size
+func poolCanHostFilesystem(pool *types.StoragePool, size uint64, thin bool) (bool, string) {
+	content := pool.StoragePoolContent
+	if content.Health.Value >= PoolHealthMajorFailure {
+		return false, fmt.Sprintf("storage pool %s health is not OK (health value: %d)", content.ID, content.Health.Value)
+	}
+	if content.FreeCapacity == 0 {
+		return false, fmt.Sprintf("storage pool %s has no free capacity", content.ID)
+	}
+	//Thin filesystems only consume the space written to them and hence the pool can be oversubscribed
+	if !thin && size > content.FreeCapacity {
+		return false, fmt.Sprintf("storage pool %s free capacity (%d) is less than the requested thick filesystem size (%d)", content.ID, content.FreeCapacity, size)
+	}
+	return true, ""
+}
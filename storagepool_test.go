@@ -2,17 +2,30 @@ package gounity
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	"github.com/dell/gounity/api"
+	"github.com/dell/gounity/types"
 )
 
 var storagePoolName string
 
 func TestStoragePool(t *testing.T) {
+	requireArray(t)
 	ctx = context.Background()
 
 	findStoragePoolByIDTest(t)
 	findStoragePoolByNameTest(t)
+	poolAlertThresholdsTest(t)
+	canPoolHostFilesystemTest(t)
+	poolUtilizationTest(t)
+	poolTiersTest(t)
+	arrayCapacitySummaryTest(t)
+	thinSubscriptionTest(t)
 }
 
 func findStoragePoolByIDTest(t *testing.T) {
@@ -26,6 +39,17 @@ func findStoragePoolByIDTest(t *testing.T) {
 	}
 	storagePoolName = pool.StoragePoolContent.Name
 
+	pool, err = testConf.poolAPI.FindStoragePoolByID(ctx, testConf.poolID, "id", "name")
+	if err != nil || pool.StoragePoolContent.Name != storagePoolName {
+		t.Fatalf("Find Pool by Id with id,name fields failed: %v", err)
+	}
+
+	rawPool := &types.StoragePool{}
+	err = testConf.client.ExecuteRaw(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceWithFieldsURI, api.PoolAction, testConf.poolID, "id,name"), nil, rawPool)
+	if err != nil || rawPool.StoragePoolContent.ID != testConf.poolID {
+		t.Fatalf("Find Pool by Id using raw request failed: %v", err)
+	}
+
 	//Negative cases
 	storagePoolIDTemp := ""
 	pool, err = testConf.poolAPI.FindStoragePoolByID(ctx, storagePoolIDTemp)
@@ -39,6 +63,13 @@ func findStoragePoolByIDTest(t *testing.T) {
 		t.Fatalf("Find Pool by Id with invalid Id case - failed: %v", err)
 	}
 
+	uri := fmt.Sprintf(api.UnityAPIGetResourceURI, api.PoolAction, storagePoolIDTemp)
+	err = testConf.client.ExecuteRaw(ctx, http.MethodGet, uri, nil, rawPool)
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) || reqErr.Method != http.MethodGet || reqErr.URI != uri || reqErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("Raw request with invalid Id did not return the request details: %v", err)
+	}
+
 	fmt.Println("Find Storage Pool by Id Test - Successful")
 }
 
@@ -67,3 +98,162 @@ func findStoragePoolByNameTest(t *testing.T) {
 
 	fmt.Println("Find Storage Pool by Name Test - Successful")
 }
+
+func poolAlertThresholdsTest(t *testing.T) {
+
+	fmt.Println("Begin - Storage Pool Alert Thresholds Test")
+
+	thresholds, err := testConf.poolAPI.GetPoolAlertThresholds(ctx, testConf.poolID)
+	fmt.Println("Pool alert thresholds:", prettyPrintJSON(thresholds), err)
+	if err != nil {
+		t.Fatalf("Get Pool alert thresholds failed: %v", err)
+	}
+
+	//Set the same threshold back to keep the pool configuration unchanged
+	err = testConf.poolAPI.SetPoolAlertThresholds(ctx, testConf.poolID, thresholds.AlertThreshold)
+	if err != nil {
+		t.Fatalf("Set Pool alert threshold failed: %v", err)
+	}
+
+	//Negative cases
+	_, err = testConf.poolAPI.GetPoolAlertThresholds(ctx, "")
+	if err == nil {
+		t.Fatalf("Get Pool alert thresholds with empty Id case - failed: %v", err)
+	}
+
+	err = testConf.poolAPI.SetPoolAlertThresholds(ctx, testConf.poolID, 99)
+	if err == nil {
+		t.Fatalf("Set Pool alert threshold with out of range value case - failed: %v", err)
+	}
+
+	fmt.Println("Storage Pool Alert Thresholds Test - Successful")
+}
+
+func canPoolHostFilesystemTest(t *testing.T) {
+
+	fmt.Println("Begin - Can Pool Host Filesystem Test")
+
+	canHost, reason, err := testConf.poolAPI.CanPoolHostFilesystem(ctx, testConf.poolID, 5368709120, true)
+	fmt.Println("Can pool host filesystem:", canHost, reason, err)
+	if err != nil {
+		t.Fatalf("Can pool host filesystem failed: %v", err)
+	}
+
+	//A thick filesystem larger than any pool can not be hosted
+	canHost, reason, err = testConf.poolAPI.CanPoolHostFilesystem(ctx, testConf.poolID, ^uint64(0), false)
+	if err != nil || canHost || reason == "" {
+		t.Fatalf("Can pool host filesystem with huge thick size case - failed: %v", err)
+	}
+
+	//Negative cases
+	_, _, err = testConf.poolAPI.CanPoolHostFilesystem(ctx, "dummy_pool_id_1", 5368709120, true)
+	if err == nil {
+		t.Fatalf("Can pool host filesystem with invalid pool Id case - failed: %v", err)
+	}
+
+	fmt.Println("Can Pool Host Filesystem Test - Successful")
+}
+
+func poolUtilizationTest(t *testing.T) {
+
+	fmt.Println("Begin - Pool Utilization Test")
+
+	utilization, err := testConf.poolAPI.GetPoolUtilization(ctx, testConf.poolID)
+	if err != nil || utilization < 0 || utilization > 100 {
+		t.Fatalf("Get pool utilization failed: %v %v", utilization, err)
+	}
+
+	pools, err := testConf.poolAPI.ListPoolsAboveUtilization(ctx, 0)
+	if err != nil {
+		t.Fatalf("List pools above utilization failed: %v", err)
+	}
+	found := false
+	for _, pool := range pools {
+		found = found || pool.StoragePoolContent.ID == testConf.poolID
+	}
+	if utilization > 0 && !found {
+		t.Fatalf("Pool %s with utilization %v not listed above 0", testConf.poolID, utilization)
+	}
+
+	//Negative cases
+	_, err = testConf.poolAPI.GetPoolUtilization(ctx, "dummy_pool_id_1")
+	if err == nil {
+		t.Fatalf("Get pool utilization with invalid Id case - failed: %v", err)
+	}
+
+	_, err = testConf.poolAPI.ListPoolsAboveUtilization(ctx, 120)
+	if err == nil {
+		t.Fatalf("List pools above utilization with invalid threshold case - failed: %v", err)
+	}
+
+	fmt.Println("Pool Utilization Test - Successful")
+}
+
+func poolTiersTest(t *testing.T) {
+	fmt.Println("Begin - Storage Pool Tiers Test")
+
+	tiers, err := testConf.poolAPI.GetPoolTiers(ctx, testConf.poolID)
+	if err != nil || len(tiers) == 0 {
+		t.Fatalf("Get storage pool tiers failed: %v", err)
+	}
+	for _, tier := range tiers {
+		fmt.Printf("Tier: %s type: %d raid type: %d disks: %d\n", tier.Name, tier.TierType, tier.RaidType, tier.DiskCount)
+	}
+
+	//Negative case
+	_, err = testConf.poolAPI.GetPoolTiers(ctx, "dummy_pool_id_1")
+	if err == nil {
+		t.Fatalf("Get storage pool tiers with invalid pool Id case - failed: %v", err)
+	}
+
+	fmt.Println("Storage Pool Tiers Test - Successful")
+}
+
+func arrayCapacitySummaryTest(t *testing.T) {
+	fmt.Println("Begin - Array Capacity Summary Test")
+
+	summary, err := testConf.poolAPI.GetArrayCapacitySummary(ctx)
+	if err != nil || summary.PoolCount == 0 || summary.SizeTotal == 0 {
+		t.Fatalf("Get array capacity summary failed: %+v %v", summary, err)
+	}
+	if summary.IsOvercommitted != (summary.SizeSubscribed > summary.SizeTotal) {
+		t.Fatalf("Array capacity summary overcommit is inconsistent: %+v", summary)
+	}
+	fmt.Println("Array capacity summary:", prettyPrintJSON(summary))
+
+	fmt.Println("Array Capacity Summary Test - Successful")
+}
+
+func thinSubscriptionTest(t *testing.T) {
+	fmt.Println("Begin - Thin Subscription Test")
+
+	subscribedPercent, err := testConf.poolAPI.GetThinSubscription(ctx)
+	if err != nil || subscribedPercent < 0 {
+		t.Fatalf("Get thin subscription failed: %v %v", subscribedPercent, err)
+	}
+	fmt.Println("Array thin subscription percentage:", subscribedPercent)
+
+	fmt.Println("Thin Subscription Test - Successful")
+}
+
+func TestThinSubscription(t *testing.T) {
+	ctx = context.Background()
+	fmt.Println("Begin - Thin Subscription Across Pools Test")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(api.HeaderKeyContentType, api.HeaderValContentTypeJSON)
+		fmt.Fprint(w, `{"entries":[{"content":{"id":"pool_1","sizeTotal":1000,"sizeSubscribed":1500}},{"content":{"id":"pool_2","sizeTotal":3000,"sizeSubscribed":1500}}]}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(ctx, server.URL, true)
+	if err != nil {
+		t.Fatalf("Create client failed: %v", err)
+	}
+	subscribedPercent, err := NewStoragePool(client).GetThinSubscription(ctx)
+	if err != nil || subscribedPercent != 75 {
+		t.Fatalf("Thin subscription across pools is not 75 percent: %v %v", subscribedPercent, err)
+	}
+
+	fmt.Println("Thin Subscription Across Pools Test - Successful")
+}
@@ -0,0 +1,81 @@
+package gounity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dell/gounity/util"
+)
+
+//Span attribute keys set on the span of each Unity REST API request
+const (
+	SpanAttributeMethod     = "http.method"
+	SpanAttributeURI        = "http.target"
+	SpanAttributeStatusCode = "http.status_code"
+	SpanAttributeRunID      = "unity.runid"
+	SpanAttributeRetried    = "unity.retried"
+)
+
+//RequestTracer starts a span for each Unity REST API request, as a child of the span in the given context. It allows
+//tracing the requests without gounity depending on a tracing library, Ex: an OpenTelemetry adapter:
+//
+//	func (t otelTracer) StartSpan(ctx context.Context, name string) (context.Context, gounity.RequestSpan) {
+//		ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+//		return ctx, otelSpan{span} //SetAttribute -> span.SetAttributes(attribute.String(..)), End -> RecordError & End
+//	}
+//
+//The returned context is used for the request, so that an instrumented transport creates it's spans below.
+type RequestTracer interface {
+	StartSpan(ctx context.Context, name string) (context.Context, RequestSpan)
+}
+
+//RequestSpan is the span of a Unity REST API request
+type RequestSpan interface {
+	SetAttribute(key string, value interface{})
+	End(err error)
+}
+
+//noopTracer is the default RequestTracer, creating no span
+type noopTracer struct{}
+
+func (noopTracer) StartSpan(ctx context.Context, name string) (context.Context, RequestSpan) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(key string, value interface{}) {}
+
+func (noopSpan) End(err error) {}
+
+//SetRequestTracer function sets the tracer starting a span for each request, spans are not created by default
+func (c *Client) SetRequestTracer(tracer RequestTracer) {
+	if tracer == nil {
+		tracer = noopTracer{}
+	}
+	c.tracer = tracer
+}
+
+//startRequestSpan starts the span of a request with the request attributes
+func (c *Client) startRequestSpan(ctx context.Context, method, uri string) (context.Context, RequestSpan) {
+	if c.tracer == nil {
+		return ctx, noopSpan{}
+	}
+	spanCtx, span := c.tracer.StartSpan(ctx, fmt.Sprintf("Unity %s", method))
+	span.SetAttribute(SpanAttributeMethod, method)
+	span.SetAttribute(SpanAttributeURI, sanitizeURI(uri))
+	if runID, ok := util.GetRunIDLogger(ctx).Data["runid"]; ok {
+		span.SetAttribute(SpanAttributeRunID, fmt.Sprint(runID))
+	}
+	return spanCtx, span
+}
+
+//endRequestSpan ends the span of a request, recording the status code of a failed request
+func endRequestSpan(span RequestSpan, err error) {
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) && reqErr.StatusCode != 0 {
+		span.SetAttribute(SpanAttributeStatusCode, reqErr.StatusCode)
+	}
+	span.End(err)
+}
@@ -0,0 +1,81 @@
+package gounity
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dell/gounity/api"
+	"github.com/dell/gounity/types"
+)
+
+func TestTracing(t *testing.T) {
+	ctx = context.Background()
+
+	requestTracerTest(t)
+}
+
+type recordingSpan struct {
+	attributes map[string]interface{}
+	ended      bool
+}
+
+func (s *recordingSpan) SetAttribute(key string, value interface{}) {
+	s.attributes[key] = value
+}
+
+func (s *recordingSpan) End(err error) {
+	s.ended = true
+}
+
+type recordingTracer struct {
+	spans []*recordingSpan
+}
+
+func (rt *recordingTracer) StartSpan(ctx context.Context, name string) (context.Context, RequestSpan) {
+	span := &recordingSpan{attributes: map[string]interface{}{}}
+	rt.spans = append(rt.spans, span)
+	return ctx, span
+}
+
+func requestTracerTest(t *testing.T) {
+	fmt.Println("Begin - Request Tracer Test")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(api.HeaderKeyContentType, api.HeaderValContentTypeJSON)
+		if r.URL.Path != fmt.Sprintf(api.UnityAPIGetResourceURI, api.PoolAction, "pool_1") {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"errorCode":131149829,"httpStatusCode":404,"messages":[{"en-US":"The requested resource does not exist."}]}}`)
+			return
+		}
+		fmt.Fprint(w, `{"content":{"id":"pool_1"}}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(ctx, server.URL, true)
+	if err != nil {
+		t.Fatalf("Create client failed: %v", err)
+	}
+	tracer := &recordingTracer{}
+	client.SetRequestTracer(tracer)
+	_, err = NewStoragePool(client).FindStoragePoolByID(ctx, "pool_1")
+	if err != nil || len(tracer.spans) != 1 || !tracer.spans[0].ended || tracer.spans[0].attributes[SpanAttributeMethod] != http.MethodGet {
+		t.Fatalf("Find Pool by Id with tracer did not record the request span: %+v %v", tracer.spans, err)
+	}
+
+	//Negative case
+	err = client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceURI, api.PoolAction, "pool_2"), nil, &types.StoragePool{})
+	if err == nil || len(tracer.spans) != 2 || tracer.spans[1].attributes[SpanAttributeStatusCode] != http.StatusNotFound {
+		t.Fatalf("Failed request did not record the status code on the span: %+v %v", tracer.spans, err)
+	}
+
+	client.SetRequestTracer(nil)
+	_, err = NewStoragePool(client).FindStoragePoolByID(ctx, "pool_1")
+	if err != nil || len(tracer.spans) != 2 {
+		t.Fatalf("Removed tracer still recorded a span: %+v %v", tracer.spans, err)
+	}
+
+	fmt.Println("Request Tracer Test Successful")
+}
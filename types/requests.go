@@ -44,6 +44,11 @@ type StoragePoolID struct {
 	PoolID string `json:"id"`
 }
 
+//StoragePoolModifyParam Struct to capture Storage pool modify parameters
+type StoragePoolModifyParam struct {
+	AlertThreshold int `json:"alertThreshold,omitempty"`
+}
+
 //NasServerID Struct to capture Nas server ID for Create Volume
 type NasServerID struct {
 	NasServerID string `json:"id"`
@@ -100,15 +105,31 @@ type FsExpandParameters struct {
 	Size uint64 `json:"size"`
 }
 
-//FsExpandModifyParam Struct to expand Filesystem
+//FsExpandModifyParam Struct to expand Filesystem. It must carry the size alone, sending the share parameters along
+//with a size change would overwrite the share configuration.
 type FsExpandModifyParam struct {
 	FsParameters *FsExpandParameters `json:"fsParameters"`
 }
 
 //FsModifyParameters Struct to modify Filesystem parameters
 type FsModifyParameters struct {
-	NFSShares   *[]NFSShareCreateParam `json:"nfsShareCreate,omitempty"`
-	Description string                 `json:"description,omitempty"`
+	NFSShares              *[]NFSShareCreateParam  `json:"nfsShareCreate,omitempty"`
+	Name                   string                  `json:"name,omitempty"`
+	Description            string                  `json:"description,omitempty"`
+	FsParameters           *FsModifyFsParameters   `json:"fsParameters,omitempty"`
+	SnapScheduleParameters *SnapScheduleParameters `json:"snapScheduleParameters,omitempty"`
+}
+
+//SnapScheduleParameters Struct to capture the snapshot auto delete policies of a storage resource
+type SnapScheduleParameters struct {
+	PoolFullPolicy  int `json:"poolFullPolicy"`
+	SpaceUsedPolicy int `json:"spaceUsedPolicy"`
+}
+
+//FsModifyFsParameters Struct to capture the File system properties to modify
+type FsModifyFsParameters struct {
+	FileEventSettings *FileEventSettings `json:"fileEventSettings,omitempty"`
+	FastVPParameters  *FastVPParameters  `json:"fastVPParameters,omitempty"`
 }
 
 //NFSShareCreateParam Struct to capture NFS Share Create parameters
@@ -124,6 +145,7 @@ type NFSShareCreateFromSnapParam struct {
 	Path          string            `json:"path"`
 	DefaultAccess string            `json:"defaultAccess,omitempty"`
 	Snapshot      SnapshotIDContent `json:"snap"`
+	IsReadOnly    *bool             `json:"isReadOnly,omitempty"`
 }
 
 //NFSShareModify Struct to modify NFS Share parameters
@@ -134,10 +156,12 @@ type NFSShareModify struct {
 //NFSShareCreateFromSnapModify Struct to modify NFS Share created from snapshot parameters
 type NFSShareCreateFromSnapModify struct {
 	DefaultAccess           string           `json:"defaultAccess,omitempty"`
+	IsReadOnly              *bool            `json:"isReadOnly,omitempty"`
 	ReadOnlyHosts           *[]HostIDContent `json:"readOnlyHosts,omitempty"`
 	ReadWriteHosts          *[]HostIDContent `json:"readWriteHosts,omitempty"`
 	ReadOnlyRootAccessHosts *[]HostIDContent `json:"readOnlyRootAccessHosts,omitempty"`
 	RootAccessHosts         *[]HostIDContent `json:"rootAccessHosts,omitempty"`
+	NoAccessHosts           *[]HostIDContent `json:"noAccessHosts,omitempty"`
 }
 
 //NFSShareDelete Struct to modify NFS Share parameters
@@ -158,6 +182,20 @@ type NFSShareParameters struct {
 	ReadWriteHosts          *[]HostIDContent `json:"readWriteHosts,omitempty"`
 	ReadOnlyRootAccessHosts *[]HostIDContent `json:"readOnlyRootAccessHosts,omitempty"`
 	RootAccessHosts         *[]HostIDContent `json:"rootAccessHosts,omitempty"`
+	NoAccessHosts           *[]HostIDContent `json:"noAccessHosts,omitempty"`
+	MinSecurity             int              `json:"minSecurity,omitempty"`
+	NFSOwnerUsername        string           `json:"nfsOwnerUsername,omitempty"`
+}
+
+//NFSShareAccessSpec Struct to capture the complete desired access of a NFS share.
+//Each host list replaces the corresponding access list of the share, an empty list clears it.
+type NFSShareAccessSpec struct {
+	DefaultAccess           string   `json:"defaultAccess"`
+	ReadOnlyHosts           []string `json:"readOnlyHosts"`
+	ReadWriteHosts          []string `json:"readWriteHosts"`
+	ReadOnlyRootAccessHosts []string `json:"readOnlyRootAccessHosts"`
+	RootAccessHosts         []string `json:"rootAccessHosts"`
+	NoAccessHosts           []string `json:"noAccessHosts"`
 }
 
 //FileEventSettings Struct to capture File event settings
@@ -190,6 +228,13 @@ type HostIDContent struct {
 	ID string `json:"id"`
 }
 
+//HostSpec Struct to capture a host to create if missing, identified by name, with it's IP addresses
+type HostSpec struct {
+	Name        string   `json:"name"`
+	TenantID    string   `json:"tenantId,omitempty"`
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+}
+
 //HostIPPortCreateParam Struct to capture Host IP Pot Request
 type HostIPPortCreateParam struct {
 	HostIDContent *HostIDContent `json:"host"`
@@ -208,6 +253,40 @@ type HostInitiatorModifyParam struct {
 	HostIDContent *HostIDContent `json:"host"`
 }
 
+//HostInitiatorCHAPModifyParam Struct to capture Host Initiator CHAP parameters
+type HostInitiatorCHAPModifyParam struct {
+	ChapUserName string `json:"chapUserName"`
+	ChapSecret   string `json:"chapSecret"`
+}
+
+//ISCSISettingsModifyParam Struct to capture the array iSCSI CHAP settings
+type ISCSISettingsModifyParam struct {
+	IsForwardCHAPRequired     bool   `json:"isForwardCHAPRequired"`
+	ForwardGlobalCHAPUserName string `json:"forwardGlobalCHAPUserName"`
+	ForwardGlobalCHAPSecret   string `json:"forwardGlobalCHAPSecret"`
+}
+
+//RouteCreateParam Struct to capture the parameters of a route created on an IP interface (Ex: NAS server file interface)
+type RouteCreateParam struct {
+	Interface      *StorageResourceParam `json:"interface"`
+	Destination    string                `json:"destination"`
+	Netmask        string                `json:"netmask,omitempty"`
+	V6PrefixLength int                   `json:"v6PrefixLength,omitempty"`
+	Gateway        string                `json:"gateway"`
+}
+
+//FileNameServerParam Struct to capture the DNS or NIS server parameters of a NAS server. The NAS server is set on create only.
+type FileNameServerParam struct {
+	NASServer *StorageResourceParam `json:"nasServer,omitempty"`
+	Domain    string                `json:"domain"`
+	Addresses []string              `json:"addresses"`
+}
+
+//AlertModifyParam Struct to capture Alert modify parameters
+type AlertModifyParam struct {
+	IsAcknowledged bool `json:"isAcknowledged"`
+}
+
 //HostAccess Struct to capture Host access parameters
 type HostAccess struct {
 	HostIDContent *HostIDContent `json:"host"`
@@ -273,3 +352,10 @@ type CreateLunThinCloneParam struct {
 
 //InitiatorType is string Type
 type InitiatorType string
+
+//BatchRequest struct to capture a resource to fetch with BatchGet
+type BatchRequest struct {
+	ResourceType string   //Unity resource type (Ex: filesystem, nfsShare)
+	ID           string   //Id of the resource
+	Fields       []string //Fields to fetch, the id is always fetched
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -11,19 +12,62 @@ type StoragePool struct {
 
 //StoragePoolContent Struct to capture the StoragePool Content properties
 type StoragePoolContent struct {
-	ID                          string     `json:"id"`
-	Name                        string     `json:"name"`
-	Description                 string     `json:"description"`
-	FreeCapacity                uint64     `json:"sizeFree"`
-	TotalCapacity               uint64     `json:"sizeTotal"`
-	UsedCapacity                uint64     `json:"sizeUsed"`
-	SubscribedCapacity          uint64     `json:"sizeSubscribed"`
-	HasDataReductionEnabledLuns bool       `json:"hasDataReductionEnabledLuns"`
-	HasDataReductionEnabledFs   bool       `json:"hasDataReductionEnabledFs"`
-	IsFASTCacheEnabled          bool       `json:"isFASTCacheEnabled"`
-	Type                        int8       `json:"type"`
-	IsAllFlash                  bool       `json:"isAllFlash"`
-	PoolFastVP                  PoolFastVP `json:"poolFastVP"`
+	ID                            string        `json:"id"`
+	Name                          string        `json:"name"`
+	Description                   string        `json:"description"`
+	FreeCapacity                  uint64        `json:"sizeFree"`
+	TotalCapacity                 uint64        `json:"sizeTotal"`
+	UsedCapacity                  uint64        `json:"sizeUsed"`
+	SubscribedCapacity            uint64        `json:"sizeSubscribed"`
+	HasDataReductionEnabledLuns   bool          `json:"hasDataReductionEnabledLuns"`
+	HasDataReductionEnabledFs     bool          `json:"hasDataReductionEnabledFs"`
+	IsFASTCacheEnabled            bool          `json:"isFASTCacheEnabled"`
+	Type                          int8          `json:"type"`
+	IsAllFlash                    bool          `json:"isAllFlash"`
+	PoolFastVP                    PoolFastVP    `json:"poolFastVP"`
+	AlertThreshold                int           `json:"alertThreshold,omitempty"`
+	IsHarvestEnabled              bool          `json:"isHarvestEnabled,omitempty"`
+	PoolSpaceHarvestHighThreshold float64       `json:"poolSpaceHarvestHighThreshold,omitempty"`
+	PoolSpaceHarvestLowThreshold  float64       `json:"poolSpaceHarvestLowThreshold,omitempty"`
+	Health                        HealthContent `json:"health,omitempty"`
+	Tiers                         []PoolTier    `json:"tiers,omitempty"`
+}
+
+//PoolTier struct to capture a tier of the storage pool with it's RAID configuration
+type PoolTier struct {
+	Name      string `json:"name"`
+	TierType  int    `json:"tierType"`
+	RaidType  int    `json:"raidType"`
+	DiskCount int    `json:"diskCount"`
+	SizeTotal uint64 `json:"sizeTotal"`
+	SizeUsed  uint64 `json:"sizeUsed"`
+	SizeFree  uint64 `json:"sizeFree"`
+}
+
+//ListStoragePools struct to capture Storage Pool list
+type ListStoragePools struct {
+	StoragePools []StoragePool `json:"entries"`
+}
+
+//PoolThresholds struct to capture space alert and space harvesting thresholds of a pool
+type PoolThresholds struct {
+	AlertThreshold                int     `json:"alertThreshold"`
+	IsHarvestEnabled              bool    `json:"isHarvestEnabled"`
+	PoolSpaceHarvestHighThreshold float64 `json:"poolSpaceHarvestHighThreshold"`
+	PoolSpaceHarvestLowThreshold  float64 `json:"poolSpaceHarvestLowThreshold"`
+}
+
+//CapacitySummary struct to capture the capacity summed across all the storage pools of the array
+type CapacitySummary struct {
+	PoolCount           int     `json:"poolCount"`
+	SizeTotal           uint64  `json:"sizeTotal"`
+	SizeUsed            uint64  `json:"sizeUsed"`
+	SizeFree            uint64  `json:"sizeFree"`
+	SizeSubscribed      uint64  `json:"sizeSubscribed"`
+	UsedPercent         float64 `json:"usedPercent"`
+	SubscriptionPercent float64 `json:"subscriptionPercent"`
+	IsOvercommitted     bool    `json:"isOvercommitted"`
+	SizeOvercommitted   uint64  `json:"sizeOvercommitted"`
 }
 
 //PoolFastVP struct to capture fastvp property of pool
@@ -81,6 +125,7 @@ type Pool struct {
 type HostAccessResponse struct {
 	HostContent HostContent `json:"host"`
 	HLU         int         `json:"hlu"`
+	AccessMask  int         `json:"accessMask,omitempty"`
 }
 
 //Link Struct to capture the link response
@@ -136,6 +181,11 @@ type Host struct {
 	HostContent HostContent `json:"content"`
 }
 
+//ListHosts struct to capture host list
+type ListHosts struct {
+	Hosts []Host `json:"entries"`
+}
+
 //HostContent struct to capture host parameters
 type HostContent struct {
 	ID              string       `json:"id"`
@@ -208,18 +258,26 @@ type Snapshot struct {
 
 //SnapshotContent struct to capture snapshot parameters
 type SnapshotContent struct {
-	ResourceID      string          `json:"id"`
-	Name            string          `json:"name"`
-	Description     string          `json:"description,omitempty"`
-	StorageResource StorageResource `json:"storageResource,omitempty"`
-	CreationTime    time.Time       `json:"creationTime,omitempty"`
-	ExpirationTime  time.Time       `json:"expirationTime,omitempty"`
-	LastRefreshTime time.Time       `json:"lastRefreshTime,omitempty"`
-	State           int             `json:"state,omitempty"`
-	Size            int64           `json:"size"`
-	IsAutoDelete    bool            `json:"isAutoDelete"`
-	AccessType      int             `json:"accessType,omitempty"`
-	ParentSnap      StorageResource `json:"parentSnap,omitempty"`
+	ResourceID      string           `json:"id"`
+	Name            string           `json:"name"`
+	Description     string           `json:"description,omitempty"`
+	StorageResource StorageResource  `json:"storageResource,omitempty"`
+	CreationTime    time.Time        `json:"creationTime,omitempty"`
+	ExpirationTime  time.Time        `json:"expirationTime,omitempty"`
+	LastRefreshTime time.Time        `json:"lastRefreshTime,omitempty"`
+	State           int              `json:"state,omitempty"`
+	Size            int64            `json:"size"`
+	IsAutoDelete    bool             `json:"isAutoDelete"`
+	AccessType      int              `json:"accessType,omitempty"`
+	ParentSnap      StorageResource  `json:"parentSnap,omitempty"`
+	SnapGroup       StorageResource  `json:"snapGroup,omitempty"`
+	HostAccess      []SnapHostAccess `json:"hostAccess,omitempty"`
+}
+
+//SnapHostAccess struct to capture a host the snapshot is attached to
+type SnapHostAccess struct {
+	Host          StorageResource `json:"host"`
+	AllowedAccess int             `json:"allowedAccess"`
 }
 
 //CopySnapshots struct to capture copy snapshot content
@@ -245,9 +303,28 @@ type StorageResourceParameters struct {
 
 //StorageResourceContent struct to capture Storage Resource content
 type StorageResourceContent struct {
-	ID         string          `json:"id"`
-	Name       string          `json:"name,omitempty"`
-	Filesystem StorageResource `json:"filesystem,omitempty"`
+	ID                     string            `json:"id"`
+	Name                   string            `json:"name,omitempty"`
+	Description            string            `json:"description,omitempty"`
+	Type                   int               `json:"type,omitempty"`
+	SizeTotal              uint64            `json:"sizeTotal,omitempty"`
+	SizeUsed               uint64            `json:"sizeUsed,omitempty"`
+	SizeAllocated          uint64            `json:"sizeAllocated,omitempty"`
+	Pools                  []Pool            `json:"pools,omitempty"`
+	Filesystem             StorageResource   `json:"filesystem,omitempty"`
+	Luns                   []StorageResource `json:"luns,omitempty"`
+	Health                 HealthContent     `json:"health,omitempty"`
+	DataReductionSizeSaved uint64            `json:"dataReductionSizeSaved,omitempty"`
+	DataReductionPercent   int               `json:"dataReductionPercent,omitempty"`
+	DataReductionRatio     float64           `json:"dataReductionRatio,omitempty"`
+}
+
+//DataReductionStats struct to capture the space saved by the data reduction of a storage resource
+type DataReductionStats struct {
+	IsDataReductionEnabled bool    `json:"isDataReductionEnabled"`
+	SizeSaved              uint64  `json:"dataReductionSizeSaved"`
+	Percent                int     `json:"dataReductionPercent"`
+	Ratio                  float64 `json:"dataReductionRatio"`
 }
 
 //IoLimitPolicy struct IO limit policy object
@@ -261,29 +338,69 @@ type IoLimitPolicyContent struct {
 	Name string `json:"name"`
 }
 
+//PageInfo struct to capture the paging details of a list response
+type PageInfo struct {
+	EntryCount int        `json:"entryCount"`
+	Links      []PageLink `json:"links,omitempty"`
+}
+
+//PageLink struct to capture a link to another page of a list response
+type PageLink struct {
+	Rel  string `json:"rel"`
+	Href string `json:"href"`
+}
+
+//HasNext returns true if the list response links to a next page
+func (p PageInfo) HasNext() bool {
+	for _, link := range p.Links {
+		if link.Rel == "next" {
+			return true
+		}
+	}
+	return false
+}
+
 //Filesystem struct to capture filesystem object
 type Filesystem struct {
 	FileContent FileContent `json:"content"`
 }
 
+//ListFilesystems struct to capture filesystem list
+type ListFilesystems struct {
+	Filesystems []Filesystem `json:"entries"`
+}
+
 //FileContent struct to capture filesystem parameters
 type FileContent struct {
-	ID                     string        `json:"id"`
-	Name                   string        `json:"name,omitempty"`
-	SizeTotal              uint64        `json:"sizeTotal,omitempty"`
-	Description            string        `json:"description,omitempty"`
-	Type                   int           `json:"type,omitempty"`
-	Format                 int           `json:"format,omitempty"`
-	HostIOSize             int64         `json:"hostIOSize,omitempty"`
-	TieringPolicy          uint64        `json:"tieringPolicy,omitempty"`
-	IsThinEnabled          bool          `json:"isThinEnabled"`
-	IsDataReductionEnabled bool          `json:"isDataReductionEnabled"`
-	Pool                   Pool          `json:"pool,omitempty"`
-	NASServer              Pool          `json:"nasServer,omitempty"`
-	StorageResource        Pool          `json:"storageResource,omitempty"`
-	NFSShare               []Share       `json:"nfsShare,omitempty"`
-	CIFSShare              []Pool        `json:"cifsShare,omitempty"`
-	Health                 HealthContent `json:"health,omitempty"`
+	ID                     string            `json:"id"`
+	Name                   string            `json:"name,omitempty"`
+	SizeTotal              uint64            `json:"sizeTotal,omitempty"`
+	SizeUsed               uint64            `json:"sizeUsed,omitempty"`
+	Description            string            `json:"description,omitempty"`
+	Type                   int               `json:"type,omitempty"`
+	Format                 int               `json:"format,omitempty"`
+	HostIOSize             int64             `json:"hostIOSize,omitempty"`
+	TieringPolicy          uint64            `json:"tieringPolicy,omitempty"`
+	IsThinEnabled          bool              `json:"isThinEnabled"`
+	IsDataReductionEnabled bool              `json:"isDataReductionEnabled"`
+	Pool                   Pool              `json:"pool,omitempty"`
+	NASServer              Pool              `json:"nasServer,omitempty"`
+	StorageResource        Pool              `json:"storageResource,omitempty"`
+	NFSShare               []Share           `json:"nfsShare,omitempty"`
+	CIFSShare              []Pool            `json:"cifsShare,omitempty"`
+	FileEventSettings      FileEventSettings `json:"fileEventSettings,omitempty"`
+	Health                 HealthContent     `json:"health,omitempty"`
+	SnapCount              int               `json:"snapCount,omitempty"`
+	SnapsSizeAllocated     uint64            `json:"snapsSizeAllocated,omitempty"`
+}
+
+//FilesystemIdentity struct to capture the array assigned identifiers of a filesystem.
+//Unity does not allow choosing identifiers when creating a filesystem and filesystems have no WWN/NAA,
+//so the filesystem Id and the storage resource Id are the stable identifiers to index a filesystem by.
+type FilesystemIdentity struct {
+	ID                string `json:"id"`                //Filesystem Id, Ex: fs_1
+	Name              string `json:"name"`              //Filesystem name
+	StorageResourceID string `json:"storageResourceId"` //Storage resource Id used for modify and delete, Ex: res_1
 }
 
 //Share object to capture NFS Share object from FileContent
@@ -294,6 +411,11 @@ type Share struct {
 	ParentSnap StorageResource `json:"snap,omitempty"`
 }
 
+//ListNFSShares struct to capture NFS Share list
+type ListNFSShares struct {
+	NFSShares []NFSShare `json:"entries"`
+}
+
 //NFSShare struct to capture NFS Share object
 type NFSShare struct {
 	NFSShareContent NFSShareContent `json:"content"`
@@ -304,11 +426,18 @@ type NFSShareContent struct {
 	ID                      string        `json:"id"`
 	Name                    string        `json:"name,omitempty"`
 	Filesystem              Pool          `json:"filesystem,omitempty"`
+	Snapshot                Pool          `json:"snap,omitempty"`
+	IsReadOnly              bool          `json:"isReadOnly,omitempty"`
+	Path                    string        `json:"path,omitempty"`
+	DefaultAccess           int           `json:"defaultAccess"`
 	ReadOnlyHosts           []HostContent `json:"readOnlyHosts,omitempty"`
 	ReadWriteHosts          []HostContent `json:"readWriteHosts,omitempty"`
 	ReadOnlyRootAccessHosts []HostContent `json:"readOnlyRootAccessHosts,omitempty"`
 	RootAccessHosts         []HostContent `json:"rootAccessHosts,omitempty"`
+	NoAccessHosts           []HostContent `json:"noAccessHosts,omitempty"`
 	ExportPaths             []string      `json:"exportPaths,omitempty"`
+	MinSecurity             int           `json:"minSecurity,omitempty"`
+	NFSOwnerUsername        string        `json:"nfsOwnerUsername,omitempty"`
 }
 
 //NASServer struct to capture NAS Server object
@@ -318,17 +447,29 @@ type NASServer struct {
 
 //NASServerContent struct to capture NAS Server object
 type NASServerContent struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name,omitempty"`
-	NFSServer NFSServer `json:"nfsServer,omitempty"`
+	ID            string    `json:"id"`
+	Name          string    `json:"name,omitempty"`
+	NFSServer     NFSServer `json:"nfsServer,omitempty"`
+	CIFSServers   []Pool    `json:"cifsServer,omitempty"`
+	HomeSP        Pool      `json:"homeSP,omitempty"`
+	CurrentSP     Pool      `json:"currentSP,omitempty"`
+	FileDNSServer Pool      `json:"fileDNSServer,omitempty"`
+	FileNISServer Pool      `json:"fileNISServer,omitempty"`
 }
 
 //NFSServer struct to capture NFS Server object
 type NFSServer struct {
-	ID           string `json:"id"`
-	Name         string `json:"name,omitempty"`
-	NFSv3Enabled bool   `json:"nfsv3Enabled"`
-	NFSv4Enabled bool   `json:"nfsv4Enabled"`
+	ID              string `json:"id"`
+	Name            string `json:"name,omitempty"`
+	NFSv3Enabled    bool   `json:"nfsv3Enabled"`
+	NFSv4Enabled    bool   `json:"nfsv4Enabled"`
+	IsSecureEnabled bool   `json:"isSecureEnabled"`
+	KdcType         int    `json:"kdcType,omitempty"`
+}
+
+//NFSServerInstance struct to capture NFS Server instance response
+type NFSServerInstance struct {
+	NFSServer NFSServer `json:"content"`
 }
 
 //ListIPInterfaces struct to capture snapshot list
@@ -355,8 +496,10 @@ type LicenseInfo struct {
 
 //LicenseInfoContent for features on Array
 type LicenseInfoContent struct {
-	IsInstalled bool `json:"isInstalled"`
-	IsValid     bool `json:"isValid"`
+	IsInstalled bool      `json:"isInstalled"`
+	IsValid     bool      `json:"isValid"`
+	Expires     time.Time `json:"expires,omitempty"`
+	IsPermanent bool      `json:"isPermanent"`
 }
 
 //HostInitiatorPath struct to capture host initiator path object
@@ -428,6 +571,24 @@ type MetricQueryResult struct {
 	Entries []MetricResultEntry `json:"entries"`
 }
 
+//MetricValueResult is response from querying historical metric values
+type MetricValueResult struct {
+	Base    string              `json:"base"`
+	Updated string              `json:"updated"`
+	Entries []MetricResultEntry `json:"entries"`
+}
+
+//MetricValue is a single value of a metric path for a storage processor and (optionally) a resource
+type MetricValue struct {
+	Path          string      `json:"path"`
+	Timestamp     string      `json:"timestamp"`
+	SP            string      `json:"sp"`
+	ResourceID    string      `json:"resourceId,omitempty"`
+	Value         float64     `json:"value"`
+	IsNumberValue bool        `json:"isNumberValue"`
+	RawValue      interface{} `json:"rawValue"`
+}
+
 //MetricContent is part of the response from /api/types/metric/instances
 type MetricContent struct {
 	ID int `json:"id"`
@@ -464,3 +625,159 @@ type MetricInstance struct {
 	Updated string     `json:"updated"`
 	Content MetricInfo `json:"content"`
 }
+
+//RemoteSystem struct to capture remote system (replication peer) object
+type RemoteSystem struct {
+	RemoteSystemContent RemoteSystemContent `json:"content"`
+}
+
+//RemoteSystemContent struct to capture remote system parameters
+type RemoteSystemContent struct {
+	ID                string        `json:"id"`
+	Name              string        `json:"name,omitempty"`
+	Model             string        `json:"model,omitempty"`
+	SerialNumber      string        `json:"serialNumber,omitempty"`
+	ManagementAddress string        `json:"managementAddress,omitempty"`
+	Health            HealthContent `json:"health,omitempty"`
+}
+
+//ListReplicationSessions struct to capture replication session list
+type ListReplicationSessions struct {
+	Sessions []ReplicationSession `json:"entries"`
+}
+
+//ReplicationSession struct to capture replication session object
+type ReplicationSession struct {
+	ReplicationSessionContent ReplicationSessionContent `json:"content"`
+}
+
+//ReplicationSessionContent struct to capture replication session parameters
+type ReplicationSessionContent struct {
+	ID                      string          `json:"id"`
+	Name                    string          `json:"name,omitempty"`
+	ReplicationResourceType int             `json:"replicationResourceType,omitempty"`
+	Status                  int             `json:"status,omitempty"`
+	Health                  HealthContent   `json:"health,omitempty"`
+	NetworkStatus           int             `json:"networkStatus,omitempty"`
+	SyncState               int             `json:"syncState,omitempty"`
+	SyncProgress            int             `json:"syncProgress,omitempty"`
+	SrcResourceID           string          `json:"srcResourceId,omitempty"`
+	DstResourceID           string          `json:"dstResourceId,omitempty"`
+	RemoteSystem            StorageResource `json:"remoteSystem,omitempty"`
+	LastSyncTime            time.Time       `json:"lastSyncTime,omitempty"`
+}
+
+//ListFileInterfaces struct to capture file interface list
+type ListFileInterfaces struct {
+	Entries []FileInterface `json:"entries"`
+}
+
+//FileInterface struct to capture file interface object
+type FileInterface struct {
+	FileInterfaceContent FileInterfaceContent `json:"content"`
+}
+
+//FileInterfaceContent struct to capture file interface (NAS server network interface) parameters
+type FileInterfaceContent struct {
+	ID             string `json:"id"`
+	Name           string `json:"name,omitempty"`
+	NASServer      Pool   `json:"nasServer,omitempty"`
+	IPPort         Pool   `json:"ipPort,omitempty"`
+	IPAddress      string `json:"ipAddress,omitempty"`
+	Netmask        string `json:"netmask,omitempty"`
+	V6PrefixLength int    `json:"v6PrefixLength,omitempty"`
+	Gateway        string `json:"gateway,omitempty"`
+	Role           int    `json:"role"`
+}
+
+//ListRoutes struct to capture route list
+type ListRoutes struct {
+	Entries []Route `json:"entries"`
+}
+
+//Route struct to capture route object
+type Route struct {
+	RouteContent RouteContent `json:"content"`
+}
+
+//RouteContent struct to capture route parameters
+type RouteContent struct {
+	ID             string `json:"id"`
+	IPInterface    Pool   `json:"ipInterface,omitempty"`
+	Destination    string `json:"destination,omitempty"`
+	Netmask        string `json:"netmask,omitempty"`
+	V6PrefixLength int    `json:"v6PrefixLength,omitempty"`
+	Gateway        string `json:"gateway,omitempty"`
+}
+
+//ListAlerts struct to capture alert list
+type ListAlerts struct {
+	Alerts []Alert `json:"entries"`
+}
+
+//Alert struct to capture alert object
+type Alert struct {
+	AlertContent AlertContent `json:"content"`
+}
+
+//AlertContent struct to capture alert parameters
+type AlertContent struct {
+	ID             string         `json:"id"`
+	Timestamp      time.Time      `json:"timestamp,omitempty"`
+	Severity       int            `json:"severity"`
+	Component      AlertComponent `json:"component,omitempty"`
+	MessageID      string         `json:"messageId,omitempty"`
+	Message        string         `json:"message,omitempty"`
+	Description    string         `json:"description,omitempty"`
+	Resolution     string         `json:"resolution,omitempty"`
+	IsAcknowledged bool           `json:"isAcknowledged"`
+	State          int            `json:"state,omitempty"`
+}
+
+//AlertComponent struct to capture the component an alert is about
+type AlertComponent struct {
+	ID       string `json:"id"`
+	Resource string `json:"resource,omitempty"`
+}
+
+//BatchResponse struct to capture a resource fetched with BatchGet
+type BatchResponse struct {
+	ResourceType string
+	ID           string
+	Found        bool            //false if no resource exists with the Id
+	Content      json.RawMessage //the resource content, to be decoded into the content struct of the resource type
+}
+
+//Quota struct to capture tree or user quota object
+type Quota struct {
+	QuotaContent QuotaContent `json:"content"`
+}
+
+//QuotaContent struct to capture tree or user quota limits
+type QuotaContent struct {
+	ID        string `json:"id"`
+	HardLimit uint64 `json:"hardLimit"`
+	SoftLimit uint64 `json:"softLimit"`
+}
+
+//ModifyResult struct to capture the outcome of the modify of a resource by a bulk modify
+type ModifyResult struct {
+	ID  string
+	Err error //nil if the resource is modified
+}
+
+//ListSystemTime struct to capture system time list
+type ListSystemTime struct {
+	Entries []SystemTime `json:"entries"`
+}
+
+//SystemTime struct to capture system time object
+type SystemTime struct {
+	SystemTimeContent SystemTimeContent `json:"content"`
+}
+
+//SystemTimeContent struct to capture the array clock
+type SystemTimeContent struct {
+	ID   string    `json:"id"`
+	Time time.Time `json:"time"`
+}
@@ -4,22 +4,32 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
+	runtimedebug "runtime/debug"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/dell/gounity/util"
 
 	"github.com/dell/gounity/api"
 	"github.com/dell/gounity/types"
+	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 const (
 	emcCsrfToken = "EMC-CSRF-TOKEN"
+	modulePath   = "github.com/dell/gounity"
 )
 
 var (
@@ -31,10 +41,21 @@ var (
 	showHTTP, _ = strconv.ParseBool(os.Getenv("GOUNITY_SHOWHTTP"))
 )
 
+//ErrArrayInMaintenance is returned while the array is in maintenance (Ex: OE upgrade), callers should back off for minutes
+var ErrArrayInMaintenance = errors.New("array is in maintenance mode")
+
+//maintenanceIndicators are the texts of the 503 error messages returned while the array is in maintenance
+var maintenanceIndicators = []string{"maintenance", "upgrade", "service mode"}
+
 //Client Struct holds the configuration & REST Client.
 type Client struct {
-	configConnect *ConfigConnect
-	api           api.Client
+	configConnect  *ConfigConnect
+	api            api.Client
+	userAgent      string
+	tracer         RequestTracer
+	limiter        requestLimiter
+	defaultHeaders map[string]string
+	logger         *logrus.Logger
 }
 
 //ConfigConnect Struct holds the endpoint & credential info.
@@ -47,14 +68,17 @@ type ConfigConnect struct {
 // Authenticate make a REST API call [/loginSessionInfo] to Unity to get authenticate the given credentials.
 // The response contains the EMC-CSRF-TOKEN and the client caches it for further communication.
 func (c *Client) Authenticate(ctx context.Context, configConnect *ConfigConnect) error {
-	log := util.GetRunIDLogger(ctx)
+	ctx = c.withLogger(ctx)
+	log := c.getLogger(ctx)
 	log.Debug("Executing Authenticate REST client")
 	c.configConnect = configConnect
-	c.api.SetToken("")
+	c.api.ClearSession()
 	headers := make(map[string]string, 3)
 	headers[api.AuthorizationHeader] = "Basic " + basicAuth(configConnect.Username, configConnect.Password)
 	headers[api.XEmcRestClient] = "true"
 	headers[api.HeaderKeyContentType] = api.HeaderValContentTypeJSON
+	headers[api.HeaderKeyUserAgent] = c.userAgent
+	c.addDefaultHeaders(headers)
 	resp, err := c.api.DoAndGetResponseBody(ctx, http.MethodGet, api.UnityAPILoginSessionInfoURI, headers, nil)
 
 	if err != nil {
@@ -89,6 +113,40 @@ func (c *Client) Authenticate(ctx context.Context, configConnect *ConfigConnect)
 	return nil
 }
 
+//ErrInvalidCredentials is returned by VerifyCredentials when the array rejects the username & password
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+//VerifyCredentials - Verify the given username & password against the array (Ex: before rotating the credentials) with
+//a separate session, ended right after. The session and credentials of the client are left untouched.
+//ErrInvalidCredentials is returned when the array rejects the credentials.
+func (c *Client) VerifyCredentials(ctx context.Context, username, password string) error {
+	log := c.getLogger(ctx)
+	if len(username) == 0 {
+		return errors.New("username shouldn't be empty")
+	}
+	verifier, err := c.WithEndpoint(c.api.Host(), username, password)
+	if err != nil {
+		return err
+	}
+	err = verifier.Authenticate(ctx, verifier.configConnect)
+	if status.Code(err) == codes.Unauthenticated {
+		return fmt.Errorf("%w: %s", ErrInvalidCredentials, username)
+	}
+	if err != nil {
+		return err
+	}
+	//Logged out without retry, re-authenticating on failure would start another session
+	headers := make(map[string]string, 3)
+	headers[api.HeaderKeyContentType] = conHeader
+	headers[api.XEmcRestClient] = "true"
+	headers[api.HeaderKeyUserAgent] = verifier.userAgent
+	verifier.addDefaultHeaders(headers)
+	if err = verifier.api.DoWithHeaders(ctx, http.MethodPost, api.UnityAPILogoutURI, headers, nil, nil); err != nil {
+		log.Warnf("Logout of the credentials verification session of user %s failed. Error: %v", username, err)
+	}
+	return nil
+}
+
 // basicAuth converts the given username & password to Base64 encoded string.
 func basicAuth(username, password string) string {
 	auth := username + ":" + password
@@ -97,14 +155,26 @@ func basicAuth(username, password string) string {
 
 // GetJSONWithRetry method responsible to make the given API call to Unity REST API Server.
 // In case if the given EMC-CSRF-TOKEN becomes invalid, retries the same operation after performing authentication.
-func (c *Client) executeWithRetryAuthenticate(ctx context.Context, method, uri string, body, resp interface{}) error {
-	log := util.GetRunIDLogger(ctx)
-	headers := make(map[string]string, 2)
+// The returned errors are *RequestError identifying the failed request.
+func (c *Client) executeWithRetryAuthenticate(ctx context.Context, method, uri string, body, resp interface{}) (err error) {
+	ctx = c.withLogger(ctx)
+	log := c.getLogger(ctx)
+	ctx, span := c.startRequestSpan(ctx, method, uri)
+	defer func() { endRequestSpan(span, err) }()
+	headers := make(map[string]string, 5)
 	headers[api.HeaderKeyAccept] = accHeader
 	headers[api.HeaderKeyContentType] = conHeader
 	headers[api.XEmcRestClient] = "true"
+	headers[api.HeaderKeyUserAgent] = c.userAgent
+	headers[api.HeaderKeyAcceptEncoding] = api.HeaderValEncodingGzip
+	c.addDefaultHeaders(headers)
+	release, err := c.limiter.acquire(ctx)
+	if err != nil {
+		return newRequestError(ctx, method, uri, err)
+	}
+	defer release()
 	log.Debug("Invoking REST API server info Method: ", method, ", URI: ", uri)
-	err := c.api.DoWithHeaders(ctx, method, uri, headers, body, resp)
+	err = c.api.DoWithHeaders(ctx, method, uri, headers, body, resp)
 	if err == nil {
 		log.Debug("Execution successful on Method: ", method, ", URI: ", uri)
 		return nil
@@ -112,21 +182,320 @@ func (c *Client) executeWithRetryAuthenticate(ctx context.Context, method, uri s
 	// check if we need to authenticate
 	if e, ok := err.(*types.Error); ok {
 		log.Debugf("Error in response. Method:%s URI:%s Error: %v JSON Error: %+v", method, uri, err, e)
+		if isArrayInMaintenance(e) {
+			log.Warnf("Array is in maintenance. Method:%s URI:%s Error: %v", method, uri, err)
+			return newRequestError(ctx, method, uri, fmt.Errorf("%w: %v", ErrArrayInMaintenance, err))
+		}
+		if isQuotaExceeded(e) {
+			log.Warnf("Quota exceeded. Method:%s URI:%s Error: %v", method, uri, err)
+			return newRequestError(ctx, method, uri, c.quotaExceededError(ctx, uri, headers, err))
+		}
 		if e.ErrorContent.HTTPStatusCode == 401 {
+			if !takeRetry(ctx) {
+				log.Warnf("Retry budget exhausted, not re-authenticating. Method:%s URI:%s Error: %v", method, uri, err)
+				return newRequestError(ctx, method, uri, fmt.Errorf("%w: %v", ErrRetryBudgetExhausted, err))
+			}
 			log.Debug("need to re-authenticate")
 			// Authenticate then try again
 			if err := c.Authenticate(ctx, c.configConnect); err != nil {
-				return fmt.Errorf("authentication failure due to: %v", err)
+				return newRequestError(ctx, method, uri, fmt.Errorf("authentication failure due to: %v", err))
 			}
 			log.Debug("Authentication success")
-			return c.api.DoWithHeaders(ctx, method, uri, headers, body, resp)
+			span.SetAttribute(SpanAttributeRetried, true)
+			if err := c.api.DoWithHeaders(ctx, method, uri, headers, body, resp); err != nil {
+				return newRequestError(ctx, method, uri, err)
+			}
+			return nil
 		}
 	} else {
 		log.Error("Error is not a type of \"*types.Error\". Error:", err)
 	}
 	log.WithError(err).Error("failed to invoke Unity REST API server")
 
-	return err
+	return newRequestError(ctx, method, uri, err)
+}
+
+//RequestError wraps the error of a failed Unity REST API request with the request details, to identify the failed
+//request among concurrent ones. The Unity error (*types.Error) or sentinel errors are available through errors.As/Is.
+type RequestError struct {
+	Method     string
+	URI        string
+	StatusCode int
+	RunID      string
+	Err        error
+}
+
+//Error returns the request details followed by the underlying error
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("%s %s failed (status: %d, runid: %s). Error: %v", e.Method, e.URI, e.StatusCode, e.RunID, e.Err)
+}
+
+//Unwrap returns the underlying error
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+//newRequestError returns a RequestError for the given request, with the status code of the Unity error if any and the
+//run id of the context logger
+func newRequestError(ctx context.Context, method, uri string, err error) *RequestError {
+	reqErr := &RequestError{
+		Method: method,
+		URI:    sanitizeURI(uri),
+		Err:    err,
+	}
+	var unityErr *types.Error
+	if errors.As(err, &unityErr) {
+		reqErr.StatusCode = unityErr.ErrorContent.HTTPStatusCode
+	}
+	if runID, ok := util.GetRunIDLogger(ctx).Data["runid"]; ok {
+		reqErr.RunID = fmt.Sprint(runID)
+	}
+	return reqErr
+}
+
+//sanitizeURI removes the credentials (user info, password/secret/token query values) from the given URI
+func sanitizeURI(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+	u.User = nil
+	query := u.Query()
+	for key := range query {
+		lowerKey := strings.ToLower(key)
+		if strings.Contains(lowerKey, "password") || strings.Contains(lowerKey, "secret") || strings.Contains(lowerKey, "token") {
+			query.Set(key, "redacted")
+			u.RawQuery = query.Encode()
+		}
+	}
+	return u.String()
+}
+
+//Storage resource type constants
+const (
+	StorageResourceTypeFilesystem       = 1
+	StorageResourceTypeConsistencyGroup = 2
+	StorageResourceTypeVMwareFS         = 4
+	StorageResourceTypeVMwareISCSI      = 8
+	StorageResourceTypeLun              = 16
+)
+
+//ErrorStorageResourceNotFound stores error for storage resource not found
+var ErrorStorageResourceNotFound = errors.New("unable to find storage resource")
+
+//StorageResourceNotFoundErrorCode stores error code for storage resource not found
+var StorageResourceNotFoundErrorCode = "0x7d13005"
+
+//FindStorageResourceByID - Find the storage resource (Ex: res_1) by it's Id, with it's type, size, pools and child
+//resources (filesystem or LUNs), so that filesystem and LUN storage resources can be handled uniformly.
+//ErrorStorageResourceNotFound is returned if no storage resource exists with the Id.
+func (c *Client) FindStorageResourceByID(ctx context.Context, storageResourceID string) (*types.StorageResourceParameters, error) {
+	if len(storageResourceID) == 0 {
+		return nil, errors.New("storage resource Id shouldn't be empty")
+	}
+	storageResourceResp := &types.StorageResourceParameters{}
+	err := c.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceWithFieldsURI, api.StorageResourceAction, storageResourceID, StorageResourceDetailDisplayFields), nil, storageResourceResp)
+	if err != nil {
+		if strings.Contains(err.Error(), StorageResourceNotFoundErrorCode) {
+			return nil, ErrorStorageResourceNotFound
+		}
+		return nil, fmt.Errorf("unable to find storage resource: %s. Error: %v", storageResourceID, err)
+	}
+	return storageResourceResp, nil
+}
+
+//ErrorMultipleResourcesFound stores error for a name matching more than one resource
+var ErrorMultipleResourcesFound = errors.New("multiple resources found with the given name")
+
+//ListResourcesByName - List a page of maxEntries resources (DefaultListPageSize if 0) of the given type having the given
+//name. Unlike the name based lookup which assumes unique names, all the matches are decoded into dest (a list struct
+//with entries), so that callers can detect ambiguous names and return ErrorMultipleResourcesFound. The returned token,
+//empty on the last page, is passed back as nextToken to list the next page.
+func (c *Client) ListResourcesByName(ctx context.Context, resType, name, fields, nextToken string, maxEntries int, dest interface{}) (string, error) {
+	if len(name) == 0 {
+		return "", errors.New("resource name shouldn't be empty")
+	}
+	var fieldList []string
+	if len(fields) > 0 {
+		fieldList = strings.Split(fields, ",")
+	}
+	return c.listPage(ctx, resType, fieldList, fmt.Sprintf("name eq \"%s\"", name), nextToken, maxEntries, dest)
+}
+
+//BatchGetMaxIDsPerQuery is the maximum number of Ids looked up by a single query of BatchGet, to bound the URI length
+const BatchGetMaxIDsPerQuery = 50
+
+//BatchGet - Fetch the given resources with as few requests as possible. The Unity REST API has no batch endpoint, the
+//requested Ids of each resource type are looked up together with a single filtered query (BatchGetMaxIDsPerQuery Ids
+//at most per query) instead of one request per resource. The responses are returned in the order of the requests.
+func (c *Client) BatchGet(ctx context.Context, requests []types.BatchRequest) ([]types.BatchResponse, error) {
+	var resTypes []string
+	idsByType := make(map[string][]string)
+	fieldsByType := make(map[string]map[string]bool)
+	for _, req := range requests {
+		if len(req.ResourceType) == 0 || len(req.ID) == 0 {
+			return nil, errors.New("resource type and Id of a batch request shouldn't be empty")
+		}
+		if _, ok := fieldsByType[req.ResourceType]; !ok {
+			resTypes = append(resTypes, req.ResourceType)
+			fieldsByType[req.ResourceType] = map[string]bool{"id": true}
+		}
+		idsByType[req.ResourceType] = append(idsByType[req.ResourceType], req.ID)
+		for _, field := range req.Fields {
+			fieldsByType[req.ResourceType][field] = true
+		}
+	}
+
+	contents := make(map[string]json.RawMessage)
+	for _, resType := range resTypes {
+		var fields []string
+		for field := range fieldsByType[resType] {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		ids := idsByType[resType]
+		for start := 0; start < len(ids); start += BatchGetMaxIDsPerQuery {
+			end := start + BatchGetMaxIDsPerQuery
+			if end > len(ids) {
+				end = len(ids)
+			}
+			var conditions []string
+			for _, id := range ids[start:end] {
+				conditions = append(conditions, fmt.Sprintf("id eq \"%s\"", id))
+			}
+			resp := &struct {
+				Entries []struct {
+					Content json.RawMessage `json:"content"`
+				} `json:"entries"`
+			}{}
+			if err := c.QueryInstances(ctx, resType, fields, strings.Join(conditions, " or "), resp); err != nil {
+				return nil, err
+			}
+			for _, entry := range resp.Entries {
+				idContent := struct {
+					ID string `json:"id"`
+				}{}
+				if err := json.Unmarshal(entry.Content, &idContent); err != nil {
+					return nil, fmt.Errorf("unable to decode %s instance. Error: %v", resType, err)
+				}
+				contents[resType+"/"+idContent.ID] = entry.Content
+			}
+		}
+	}
+
+	responses := make([]types.BatchResponse, 0, len(requests))
+	for _, req := range requests {
+		content, found := contents[req.ResourceType+"/"+req.ID]
+		responses = append(responses, types.BatchResponse{
+			ResourceType: req.ResourceType,
+			ID:           req.ID,
+			Found:        found,
+			Content:      content,
+		})
+	}
+	return responses, nil
+}
+
+//GetSystemTime - Get the current time of the array clock, in UTC like all the times returned by the array (Ex: the
+//snapshot creation and expiration times). Comparing it with the local clock gives the skew to compensate for.
+func (c *Client) GetSystemTime(ctx context.Context) (time.Time, error) {
+	systemTimeResp := &types.ListSystemTime{}
+	err := c.QueryInstances(ctx, api.SystemTimeAction, []string{"id", "time"}, "", systemTimeResp)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(systemTimeResp.Entries) == 0 {
+		return time.Time{}, errors.New("array system time not found")
+	}
+	return systemTimeResp.Entries[0].SystemTimeContent.Time.UTC(), nil
+}
+
+//resourceExists checks whether a resource of the given type exists with the given Id or name. Only the id field of the
+//matching instances is fetched, a missing resource is (false, nil).
+func (c *Client) resourceExists(ctx context.Context, resType, nameOrID string) (bool, error) {
+	if len(nameOrID) == 0 {
+		return false, errors.New("resource name or Id shouldn't be empty")
+	}
+	resp := &struct {
+		Entries []struct {
+			Content struct {
+				ID string `json:"id"`
+			} `json:"content"`
+		} `json:"entries"`
+	}{}
+	err := c.QueryInstances(ctx, resType, []string{"id"}, fmt.Sprintf("id eq \"%s\" or name eq \"%s\"", nameOrID, nameOrID), resp)
+	if err != nil {
+		return false, err
+	}
+	return len(resp.Entries) > 0, nil
+}
+
+//QueryInstances - Query the instances of the given resource type matching the given Unity filter expression
+//(Ex: pool.id eq "pool_1"), filtered by the array. The matching instances with the given fields are decoded into
+//dest (a list struct with entries). An empty filter returns all the instances.
+func (c *Client) QueryInstances(ctx context.Context, resType string, fields []string, filter string, dest interface{}) error {
+	if len(resType) == 0 {
+		return errors.New("resource type shouldn't be empty")
+	}
+	query := url.Values{}
+	if len(fields) > 0 {
+		query.Set("fields", strings.Join(fields, ","))
+	}
+	if len(filter) > 0 {
+		query.Set("filter", filter)
+	}
+	queryURI := fmt.Sprintf(api.UnityAPIInstanceTypeResources, resType)
+	if len(query) > 0 {
+		queryURI = queryURI + "?" + query.Encode()
+	}
+	err := c.executeWithRetryAuthenticate(ctx, http.MethodGet, queryURI, nil, dest)
+	if err != nil {
+		return fmt.Errorf("unable to query %s instances with filter: %s. Error: %w", resType, filter, err)
+	}
+	return nil
+}
+
+//ExecuteRaw - Execute a request on an arbitrary Unity REST API URI (Ex: /api/instances/pool/pool_1/action/modify)
+//with the authenticated client, including the re-authentication retry and logging. The body is sent JSON encoded and the
+//response is decoded into dest when not nil. Meant for endpoints not wrapped yet by this package.
+func (c *Client) ExecuteRaw(ctx context.Context, method, uri string, body interface{}, dest interface{}) error {
+	if len(method) == 0 {
+		return errors.New("method shouldn't be empty")
+	}
+	if len(uri) == 0 {
+		return errors.New("uri shouldn't be empty")
+	}
+	return c.executeWithRetryAuthenticate(ctx, method, uri, body, dest)
+}
+
+//isResponseLost returns true for the transport errors of requests which may have reached the array without the
+//response reaching the client (Ex: connection reset, timeout), as opposed to the errors returned by the array and the
+//errors of requests never sent (Ex: failed re-authentication, exhausted retry budget, request limiter timeout). A create
+//failing this way may have created the resource, so the create methods look it up by name before reporting the failure.
+func isResponseLost(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	//context errors are net.Errors too, they are only transport errors wrapped in a *url.Error
+	var netErr net.Error
+	return errors.As(err, &netErr) && !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled)
+}
+
+//isArrayInMaintenance returns true for the 503 errors returned by an array in maintenance
+func isArrayInMaintenance(e *types.Error) bool {
+	if e.ErrorContent.HTTPStatusCode != http.StatusServiceUnavailable {
+		return false
+	}
+	for _, message := range e.ErrorContent.Message {
+		text := strings.ToLower(message.EnUS)
+		for _, indicator := range maintenanceIndicators {
+			if strings.Contains(text, indicator) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 //SetToken function sets token
@@ -134,6 +503,167 @@ func (c *Client) SetToken(token string) {
 	c.api.SetToken(token)
 }
 
+//SetUserAgent function overrides the User-Agent header sent with every request (default: gounity/<version>)
+func (c *Client) SetUserAgent(userAgent string) {
+	c.userAgent = userAgent
+}
+
+//GetUserAgent function gets the User-Agent header sent with every request
+func (c *Client) GetUserAgent() string {
+	return c.userAgent
+}
+
+//SetDefaultHeaders function sets extra headers sent with every request (Ex: a routing header required by an API
+//gateway), replacing the ones set before. The headers set by gounity (Ex: Content-Type, User-Agent) and the session
+//headers (Authorization, Cookie, EMC-CSRF-TOKEN) are not overridden.
+func (c *Client) SetDefaultHeaders(headers map[string]string) {
+	defaultHeaders := make(map[string]string, len(headers))
+	for header, value := range headers {
+		defaultHeaders[http.CanonicalHeaderKey(header)] = value
+	}
+	c.defaultHeaders = defaultHeaders
+}
+
+//addDefaultHeaders adds the default headers to the headers of a request, except the ones already set or reserved
+func (c *Client) addDefaultHeaders(headers map[string]string) {
+	if len(c.defaultHeaders) == 0 {
+		return
+	}
+	set := make(map[string]bool, len(headers))
+	for header := range headers {
+		set[http.CanonicalHeaderKey(header)] = true
+	}
+	for header, value := range c.defaultHeaders {
+		switch {
+		case set[header]:
+		case header == api.AuthorizationHeader || header == "Cookie" || header == http.CanonicalHeaderKey(api.HeaderEMCCSRFToken):
+		default:
+			headers[header] = value
+		}
+	}
+}
+
+//SetLogLevel function sets the level of the logs of this client only, the gounity logger used by the other clients is
+//not changed. The client logger writes to the output of the gounity logger with it's formatter and hooks.
+func (c *Client) SetLogLevel(level logrus.Level) {
+	if c.logger == nil {
+		c.logger = copyLogger(util.GetLogger())
+	}
+	c.logger.SetLevel(level)
+}
+
+//copyLogger returns a new logger with the level, output, formatter and hooks of the given logger
+func copyLogger(log *logrus.Logger) *logrus.Logger {
+	logger := logrus.New()
+	logger.Out = log.Out
+	logger.Formatter = log.Formatter
+	logger.ReportCaller = log.ReportCaller
+	logger.ExitFunc = log.ExitFunc
+	logger.Level = log.GetLevel()
+	for logLevel, hooks := range log.Hooks {
+		logger.Hooks[logLevel] = append([]logrus.Hook(nil), hooks...)
+	}
+	return logger
+}
+
+//getLogger returns the logger of the client set with SetLogLevel if any, the gounity logger otherwise
+func (c *Client) getLogger(ctx context.Context) *logrus.Entry {
+	return util.GetRunIDLoggerFor(ctx, c.logger)
+}
+
+//withLogger returns a context with the logger of the client set with SetLogLevel, so that the logs of the REST calls
+//made with the context honour the client log level
+func (c *Client) withLogger(ctx context.Context) context.Context {
+	if c.logger == nil {
+		return ctx
+	}
+	return util.WithLogger(ctx, c.getLogger(ctx))
+}
+
+//defaultUserAgent returns gounity/<version>, the version being the module version gounity is built with
+func defaultUserAgent() string {
+	version := "unknown"
+	if info, ok := runtimedebug.ReadBuildInfo(); ok {
+		if info.Main.Path == modulePath && info.Main.Version != "" {
+			version = info.Main.Version
+		}
+		for _, dep := range info.Deps {
+			if dep.Path == modulePath {
+				version = dep.Version
+			}
+		}
+	}
+	return "gounity/" + version
+}
+
+//SetStrictJSONDecoding function makes response decoding fail on fields unknown to the response types, so that
+//schema drift of the array surfaces as errors. Meant for development and testing, decoding is lenient by default.
+func (c *Client) SetStrictJSONDecoding(strict bool) {
+	c.api.SetStrictDecoding(strict)
+}
+
+//SetPinnedCertificate function makes the client trust only the array certificate with the given SHA-256 fingerprint
+//(hex, optionally colon separated), allowing a self-signed array certificate without disabling certificate validation.
+//The proxy & other TLS settings of the transport are kept, an error is returned when the transport is not an
+//*http.Transport (Ex: a wrapping transport given to NewClientWithHTTPClient), as it can't be cloned.
+func (c *Client) SetPinnedCertificate(fingerprint string) error {
+	return c.api.SetPinnedCertificate(fingerprint)
+}
+
+//WithEndpoint returns a new client for the given endpoint & credentials, copying the transport (TLS), timeout,
+//User-Agent, default headers and tracer settings of this client. The new client authenticates on it's first request.
+//A certificate pinned with SetPinnedCertificate is not carried over, pin the certificate of the new endpoint on the
+//returned client if needed. The new client has it's own logger with the level & output of this client's logger, and it's
+//own request limiter with the same maximum of concurrent requests, the requests in flight to this client's array don't
+//count against it.
+func (c *Client) WithEndpoint(endpoint, username, password string) (*Client, error) {
+	ac, err := c.api.WithHost(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create HTTP client for endpoint: %s. Error: %v", endpoint, err)
+	}
+	var logger *logrus.Logger
+	if c.logger != nil {
+		logger = copyLogger(c.logger)
+	}
+	derived := &Client{
+		api: ac,
+		configConnect: &ConfigConnect{
+			Endpoint: endpoint,
+			Username: username,
+			Password: password,
+		},
+		userAgent:      c.userAgent,
+		tracer:         c.tracer,
+		defaultHeaders: c.defaultHeaders,
+		logger:         logger,
+	}
+	derived.SetMaxConcurrentRequests(c.limiter.capacity())
+	return derived, nil
+}
+
+//SetSessionStore function replaces the store holding the EMC-CSRF-TOKEN and the session cookies (Ex: to simulate an
+//expired token in tests)
+func (c *Client) SetSessionStore(store api.SessionStore) {
+	c.api.SetSessionStore(store)
+}
+
+//ExportSession - Encode the session with the array (cookies, EMC-CSRF-TOKEN and creation time) so that it can be
+//persisted and imported by another process with ImportSession, avoiding a login per short-lived process
+func (c *Client) ExportSession() ([]byte, error) {
+	return c.api.ExportSession()
+}
+
+//ImportSession - Restore a session exported by ExportSession. A session older than api.SessionMaxAge is not
+//imported, the client re-authenticates instead when it has the credentials (Ex: authenticated before), else
+//api.ErrSessionExpired is returned.
+func (c *Client) ImportSession(ctx context.Context, data []byte) error {
+	err := c.api.ImportSession(data)
+	if errors.Is(err, api.ErrSessionExpired) && c.configConnect != nil && c.configConnect.Username != "" && c.configConnect.Password != "" {
+		return c.Authenticate(ctx, c.configConnect)
+	}
+	return err
+}
+
 //GetToken function gets token
 func (c *Client) GetToken() string {
 	return c.api.GetToken()
@@ -147,6 +677,12 @@ func NewClient(ctx context.Context) (client *Client, err error) {
 
 // NewClientWithArgs initialize the new REST Client with the given arguments.
 func NewClientWithArgs(ctx context.Context, endpoint string, insecure bool) (client *Client, err error) {
+	return NewClientWithProxy(ctx, endpoint, insecure, "")
+}
+
+// NewClientWithProxy initialize the new REST Client sending the requests through the given HTTP(S) proxy URL.
+// An empty proxy URL uses the HTTP_PROXY, HTTPS_PROXY & NO_PROXY environment variables.
+func NewClientWithProxy(ctx context.Context, endpoint string, insecure bool, proxyURL string) (client *Client, err error) {
 	log := util.GetRunIDLogger(ctx)
 	if showHTTP {
 		debug = true
@@ -157,6 +693,7 @@ func NewClientWithArgs(ctx context.Context, endpoint string, insecure bool) (cli
 		"insecure": insecure,
 		"debug":    debug,
 		"showHTTP": showHTTP,
+		"proxy":    sanitizeURI(proxyURL),
 	}
 
 	log.WithFields(fields).Debug("unity client init")
@@ -169,6 +706,7 @@ func NewClientWithArgs(ctx context.Context, endpoint string, insecure bool) (cli
 	opts := api.ClientOptions{
 		Insecure: insecure,
 		ShowHTTP: showHTTP,
+		Proxy:    proxyURL,
 	}
 
 	ac, err := api.New(ctx, endpoint, opts, debug)
@@ -179,11 +717,38 @@ func NewClientWithArgs(ctx context.Context, endpoint string, insecure bool) (cli
 	client = &Client{
 		api:           ac,
 		configConnect: &ConfigConnect{},
+		userAgent:     defaultUserAgent(),
 	}
 	conHeader = api.HeaderValContentTypeJSON
 	return client, nil
 }
 
+// NewClientWithHTTPClient initialize the new REST Client sending the requests through the given HTTP client, Ex: with a
+// proxy or an OpenTelemetry instrumented transport. Authentication, retries and logging are layered on top of it.
+func NewClientWithHTTPClient(ctx context.Context, endpoint string, httpClient *http.Client) (*Client, error) {
+	if endpoint == "" {
+		return nil, errors.New("endpoint is required")
+	}
+	if httpClient == nil {
+		return nil, errors.New("HTTP client is required")
+	}
+	if showHTTP {
+		debug = true
+	}
+
+	ac, err := api.NewWithHTTPClient(ctx, endpoint, httpClient, api.ClientOptions{ShowHTTP: showHTTP}, debug)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create HTTP client %v", err)
+	}
+
+	conHeader = api.HeaderValContentTypeJSON
+	return &Client{
+		api:           ac,
+		configConnect: &ConfigConnect{},
+		userAgent:     defaultUserAgent(),
+	}, nil
+}
+
 func withFields(fields map[string]interface{}, message string) error {
 	return withFieldsE(fields, message, nil)
 }
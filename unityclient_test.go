@@ -0,0 +1,365 @@
+package gounity
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dell/gounity/api"
+	"github.com/dell/gounity/types"
+	"github.com/dell/gounity/util"
+	"github.com/sirupsen/logrus"
+)
+
+func TestUnityClient(t *testing.T) {
+	ctx = context.Background()
+
+	responseLostTest(t)
+	importSessionTest(t)
+	defaultHeadersTest(t)
+	clientLogLevelTest(t)
+	withEndpointTest(t)
+	queryErrorChainTest(t)
+	arrayInMaintenanceTest(t)
+}
+
+func TestUnityClientArray(t *testing.T) {
+	requireArray(t)
+	ctx = context.Background()
+
+	verifyCredentialsTest(t)
+	systemTimeTest(t)
+	batchGetTest(t)
+}
+
+func verifyCredentialsTest(t *testing.T) {
+	fmt.Println("Begin - Verify Credentials Test")
+
+	err := testConf.client.VerifyCredentials(ctx, testConf.username, testConf.password)
+	if err != nil {
+		t.Fatalf("Verify credentials failed: %v", err)
+	}
+
+	//Negative case
+	err = testConf.client.VerifyCredentials(ctx, testConf.username, "dummy-password")
+	if !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("Verify credentials with invalid password case - failed: %v", err)
+	}
+
+	fmt.Println("Verify Credentials Test Successful")
+}
+
+func systemTimeTest(t *testing.T) {
+	fmt.Println("Begin - System Time Test")
+
+	systemTime, err := testConf.client.GetSystemTime(ctx)
+	if err != nil || systemTime.IsZero() {
+		t.Fatalf("Get system time failed: %v", err)
+	}
+	fmt.Println("Array clock skew:", time.Since(systemTime))
+
+	fmt.Println("System Time Test Successful")
+}
+
+func batchGetTest(t *testing.T) {
+	fmt.Println("Begin - Batch Get Test")
+
+	pool, err := testConf.poolAPI.FindStoragePoolByID(ctx, testConf.poolID, "id", "name")
+	if err != nil {
+		t.Fatalf("Find Pool by Id failed: %v", err)
+	}
+	batchResp, err := testConf.client.BatchGet(ctx, []types.BatchRequest{
+		{ResourceType: api.PoolAction, ID: testConf.poolID, Fields: []string{"name"}},
+		{ResourceType: api.PoolAction, ID: "dummy_pool_id_1"},
+	})
+	if err != nil || len(batchResp) != 2 || !batchResp[0].Found || batchResp[1].Found {
+		t.Fatalf("Batch get of pools failed: %+v %v", batchResp, err)
+	}
+	batchPool := &types.StoragePoolContent{}
+	if err = json.Unmarshal(batchResp[0].Content, batchPool); err != nil || batchPool.Name != pool.StoragePoolContent.Name {
+		t.Fatalf("Batch get of pools returned invalid content: %s %v", batchResp[0].Content, err)
+	}
+
+	fmt.Println("Batch Get Test Successful")
+}
+
+func responseLostTest(t *testing.T) {
+	fmt.Println("Begin - Response Lost Test")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		//Close the connection without a response, as if it was reset after the array received the request
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(ctx, server.URL, true)
+	if err != nil {
+		t.Fatalf("Create client failed: %v", err)
+	}
+	uri := fmt.Sprintf(api.UnityAPIInstanceTypeResources, api.LunAction)
+	err = client.executeWithRetryAuthenticate(ctx, http.MethodPost, uri, nil, nil)
+	if !isResponseLost(err) {
+		t.Fatalf("Request with the connection closed not reported as response lost: %v", err)
+	}
+
+	//Negative case
+	client.SetMaxConcurrentRequests(1)
+	release, err := client.limiter.acquire(ctx)
+	if err != nil {
+		t.Fatalf("Acquire request slot failed: %v", err)
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	err = client.executeWithRetryAuthenticate(timeoutCtx, http.MethodPost, uri, nil, nil)
+	release()
+	if err == nil || isResponseLost(err) {
+		t.Fatalf("Request never sent due to the request limiter reported as response lost: %v", err)
+	}
+	notSentErrors := []error{
+		fmt.Errorf("%w: %v", ErrRetryBudgetExhausted, &types.Error{}),
+		fmt.Errorf("authentication failure due to: %v", err),
+		&types.Error{},
+	}
+	for _, notSentErr := range notSentErrors {
+		if isResponseLost(newRequestError(ctx, http.MethodPost, uri, notSentErr)) {
+			t.Fatalf("Error not from the transport reported as response lost: %v", notSentErr)
+		}
+	}
+	if isResponseLost(errors.New("some error")) {
+		t.Fatal("Plain error reported as response lost")
+	}
+
+	fmt.Println("Response Lost Test Successful")
+}
+
+func importSessionTest(t *testing.T) {
+	fmt.Println("Begin - Import Session Test")
+
+	var logins int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == api.UnityAPILoginSessionInfoURI {
+			atomic.AddInt32(&logins, 1)
+			w.Header().Set(emcCsrfToken, "token-1")
+		}
+		w.Header().Set(api.HeaderKeyContentType, api.HeaderValContentTypeJSON)
+		fmt.Fprint(w, `{"content":{"id":"user_1"}}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(ctx, server.URL, true)
+	if err != nil {
+		t.Fatalf("Create client failed: %v", err)
+	}
+	stale, err := json.Marshal(map[string]interface{}{
+		"host":    client.api.Host(),
+		"token":   "token-0",
+		"created": time.Now().Add(-2 * api.SessionMaxAge),
+	})
+	if err != nil {
+		t.Fatalf("Encode session failed: %v", err)
+	}
+
+	//A fresh client has no credentials to re-authenticate with
+	err = client.ImportSession(ctx, stale)
+	if !errors.Is(err, api.ErrSessionExpired) || atomic.LoadInt32(&logins) != 0 {
+		t.Fatalf("Import of an expired session on a new client case - failed: %v", err)
+	}
+
+	err = client.Authenticate(ctx, &ConfigConnect{Endpoint: server.URL, Username: "user", Password: "password"})
+	if err != nil {
+		t.Fatalf("Authenticate failed: %v", err)
+	}
+	err = client.ImportSession(ctx, stale)
+	if err != nil || atomic.LoadInt32(&logins) != 2 || client.GetToken() != "token-1" {
+		t.Fatalf("Import of an expired session did not re-authenticate, logins: %d error: %v", atomic.LoadInt32(&logins), err)
+	}
+
+	fmt.Println("Import Session Test Successful")
+}
+
+func defaultHeadersTest(t *testing.T) {
+	fmt.Println("Begin - Default Headers Test")
+
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.Header().Set(api.HeaderKeyContentType, api.HeaderValContentTypeJSON)
+		fmt.Fprint(w, `{"content":{"id":"pool_1"}}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(ctx, server.URL, true)
+	if err != nil {
+		t.Fatalf("Create client failed: %v", err)
+	}
+	client.SetDefaultHeaders(map[string]string{
+		"x-gateway-route":           "array-1",
+		api.HeaderKeyContentType:    "text/plain",
+		api.HeaderEMCCSRFToken:      "dummy-token",
+		api.HeaderKeyAcceptEncoding: "identity",
+	})
+	err = client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceURI, api.PoolAction, "pool_1"), nil, &types.StoragePool{})
+	if err != nil {
+		t.Fatalf("Request with default headers failed: %v", err)
+	}
+	if received.Get("X-Gateway-Route") != "array-1" {
+		t.Fatalf("Default header not sent, headers: %v", received)
+	}
+
+	//Negative case
+	if received.Get(api.HeaderKeyContentType) != api.HeaderValContentTypeJSON || received.Get(api.HeaderEMCCSRFToken) != "" {
+		t.Fatalf("Default headers overrode the gounity headers, headers: %v", received)
+	}
+
+	fmt.Println("Default Headers Test - Successful")
+}
+
+func queryErrorChainTest(t *testing.T) {
+	fmt.Println("Begin - Query Error Chain Test")
+
+	client, server := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(api.HeaderKeyContentType, "text/plain")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, "The storage system is in maintenance mode")
+	})
+	defer server.Close()
+
+	checkErr := func(name string, err error) {
+		requestErr := &RequestError{}
+		if !errors.Is(err, ErrArrayInMaintenance) || !errors.As(err, &requestErr) {
+			t.Fatalf("%s lost the request error chain: %v", name, err)
+		}
+	}
+	err := client.QueryInstances(ctx, api.PoolAction, []string{"id"}, "", &types.ListStoragePools{})
+	checkErr("Query instances", err)
+	_, err = client.GetSystemTime(ctx)
+	checkErr("Get system time", err)
+	_, err = client.listPage(ctx, api.PoolAction, []string{"id"}, "", "", 10, &types.ListStoragePools{})
+	checkErr("List page", err)
+
+	fmt.Println("Query Error Chain Test Successful")
+}
+
+func withEndpointTest(t *testing.T) {
+	fmt.Println("Begin - With Endpoint Test")
+
+	client, server := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(api.HeaderKeyContentType, api.HeaderValContentTypeJSON)
+		fmt.Fprint(w, `{"content":{"id":"pool_1"}}`)
+	})
+	defer server.Close()
+	output := &bytes.Buffer{}
+	client.SetLogLevel(logrus.DebugLevel)
+	client.logger.SetOutput(output)
+	client.SetMaxConcurrentRequests(2)
+
+	derived, err := client.WithEndpoint(server.URL, "user", "password")
+	if err != nil {
+		t.Fatalf("With endpoint failed: %v", err)
+	}
+	if derived.logger == client.logger || derived.logger.GetLevel() != logrus.DebugLevel || derived.logger.Out != output {
+		t.Fatalf("With endpoint did not copy the client logger: %+v", derived.logger)
+	}
+	if derived.limiter.capacity() != 2 {
+		t.Fatalf("With endpoint did not copy the maximum of concurrent requests: %d", derived.limiter.capacity())
+	}
+
+	derived.SetLogLevel(logrus.ErrorLevel)
+	derived.SetMaxConcurrentRequests(0)
+	if client.logger.GetLevel() != logrus.DebugLevel {
+		t.Fatalf("Log level of the derived client changed the client log level to %v", client.logger.GetLevel())
+	}
+	if client.limiter.capacity() != 2 {
+		t.Fatalf("Maximum of concurrent requests of the derived client changed the client maximum to %d", client.limiter.capacity())
+	}
+
+	fmt.Println("With Endpoint Test Successful")
+}
+
+func clientLogLevelTest(t *testing.T) {
+	fmt.Println("Begin - Client Log Level Test")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(api.HeaderKeyContentType, api.HeaderValContentTypeJSON)
+		fmt.Fprint(w, `{"content":{"id":"pool_1"}}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(ctx, server.URL, true)
+	if err != nil {
+		t.Fatalf("Create client failed: %v", err)
+	}
+	globalLevel := util.GetLogger().GetLevel()
+	output := &bytes.Buffer{}
+	client.SetLogLevel(logrus.DebugLevel)
+	client.logger.SetOutput(output)
+	err = client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceURI, api.PoolAction, "pool_1"), nil, &types.StoragePool{})
+	if err != nil {
+		t.Fatalf("Request with client log level failed: %v", err)
+	}
+	if output.Len() == 0 {
+		t.Fatalf("Debug logs not written to the client logger")
+	}
+	if util.GetLogger().GetLevel() != globalLevel {
+		t.Fatalf("Client log level changed the gounity log level to %v", util.GetLogger().GetLevel())
+	}
+
+	//Negative case
+	output.Reset()
+	client.SetLogLevel(logrus.ErrorLevel)
+	err = client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceURI, api.PoolAction, "pool_1"), nil, &types.StoragePool{})
+	if err != nil {
+		t.Fatalf("Request with client log level failed: %v", err)
+	}
+	if output.Len() != 0 {
+		t.Fatalf("Logs below the client log level written: %s", output.String())
+	}
+
+	fmt.Println("Client Log Level Test - Successful")
+}
+
+func arrayInMaintenanceTest(t *testing.T) {
+	fmt.Println("Begin - Array In Maintenance Test")
+
+	bodies := map[string]string{
+		api.HeaderValContentTypeJSON: `{"error":{"errorCode":131149826,"httpStatusCode":503,"messages":[{"en-US":"The system is in service mode for an upgrade."}]}}`,
+		"text/plain":                 "Service Unavailable - the storage system is being upgraded, please retry later",
+		"text/html":                  "<html><body><h1>Maintenance in progress</h1></body></html>",
+	}
+	for contentType, body := range bodies {
+		client, server := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(api.HeaderKeyContentType, contentType)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, body)
+		})
+		err := client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceURI, api.PoolAction, "pool_1"), nil, &types.StoragePool{})
+		server.Close()
+		if !errors.Is(err, ErrArrayInMaintenance) {
+			t.Fatalf("503 response with a %s body did not return ErrArrayInMaintenance: %v", contentType, err)
+		}
+	}
+
+	//Negative case
+	client, server := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(api.HeaderKeyContentType, "text/plain")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, "Too many requests")
+	})
+	defer server.Close()
+	err := client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceURI, api.PoolAction, "pool_1"), nil, &types.StoragePool{})
+	if err == nil || errors.Is(err, ErrArrayInMaintenance) {
+		t.Fatalf("503 response without maintenance text returned ErrArrayInMaintenance: %v", err)
+	}
+
+	fmt.Println("Array In Maintenance Test Successful")
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"os"
+	"path"
 	"reflect"
 	"regexp"
 	"runtime"
@@ -20,6 +22,7 @@ var (
 	ErrorNameEmpty         = errors.New("name empty error")
 	ErrorNameTooLong       = errors.New("name too long error")
 	ErrorInvalidCharacters = errors.New("name contains invalid characters or name doesn't start with alphabetic. Allowed characters are 'a-zA-Z0-9_-'")
+	ErrorInvalidIPAddress  = errors.New("invalid IPv4 or IPv6 address")
 )
 
 //UnityLog constant
@@ -32,8 +35,20 @@ type UnityLogStruct struct {
 	unityLog string
 }
 
-//GetRunIDLogger function returns entry if exists
+//loggerKey is the context key of the logger set with WithLogger
+type loggerKey struct{}
+
+//WithLogger function returns a context with the given logger, used by gounity for all the log output of the calls made
+//with the context instead of the gounity logger (Ex: a logger with the fields, hooks or output of the caller)
+func WithLogger(ctx context.Context, logger *logrus.Entry) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+//GetRunIDLogger function returns the logger set with WithLogger if any, the entry if exists otherwise
 func GetRunIDLogger(ctx context.Context) *logrus.Entry {
+	if logger, ok := ctx.Value(loggerKey{}).(*logrus.Entry); ok && logger != nil {
+		return logger
+	}
 	rlog := ctx.Value(UnityLog)
 	entry := &logrus.Entry{}
 	if rlog != nil && reflect.TypeOf(rlog) == reflect.TypeOf(entry) {
@@ -47,6 +62,19 @@ func GetRunIDLogger(ctx context.Context) *logrus.Entry {
 	return log.WithContext(ctx)
 }
 
+//GetRunIDLoggerFor function returns the logger set with WithLogger if any, the given logger with the fields of the
+//entry otherwise. A nil logger gives the same as GetRunIDLogger.
+func GetRunIDLoggerFor(ctx context.Context, logger *logrus.Logger) *logrus.Entry {
+	if ctxLogger, ok := ctx.Value(loggerKey{}).(*logrus.Entry); ok && ctxLogger != nil {
+		return ctxLogger
+	}
+	entry := GetRunIDLogger(ctx)
+	if logger == nil {
+		return entry
+	}
+	return logger.WithFields(entry.Data).WithContext(ctx)
+}
+
 var singletonLog *logrus.Logger
 var once sync.Once
 
@@ -161,3 +189,61 @@ func ValidateDuration(durationStr string) (uint64, error) {
 
 	return 0, nil
 }
+
+//ParseIPAddress function validates the given IPv4 or IPv6 address, brackets and zone are allowed for IPv6
+func ParseIPAddress(ipAddress string) (net.IP, error) {
+	ipAddress = strings.TrimSpace(ipAddress)
+	ipAddress = strings.TrimSuffix(strings.TrimPrefix(ipAddress, "["), "]")
+	if i := strings.Index(ipAddress, "%"); i >= 0 {
+		ipAddress = ipAddress[:i]
+	}
+	ip := net.ParseIP(ipAddress)
+	if ip == nil {
+		return nil, ErrorInvalidIPAddress
+	}
+	return ip, nil
+}
+
+//IsIPv6Address function returns true if the given address is a valid IPv6 address
+func IsIPv6Address(ipAddress string) bool {
+	ip, err := ParseIPAddress(ipAddress)
+	return err == nil && ip.To4() == nil
+}
+
+//ValidateIPPrefixLength function validates the prefix length (netmask bits) for the given IPv4 or IPv6 address
+func ValidateIPPrefixLength(ipAddress string, prefixLength int) error {
+	ip, err := ParseIPAddress(ipAddress)
+	if err != nil {
+		return err
+	}
+	maxLength := net.IPv6len * 8
+	if ip.To4() != nil {
+		maxLength = net.IPv4len * 8
+	}
+	if prefixLength < 1 || prefixLength > maxLength {
+		return fmt.Errorf("prefix length %d for %s should be in between 1-%d", prefixLength, ipAddress, maxLength)
+	}
+	return nil
+}
+
+//GetNFSExportPath function returns the NFS export path in <ip>:<path> form, IPv6 addresses are enclosed in brackets
+func GetNFSExportPath(ipAddress, sharePath string) (string, error) {
+	ip, err := ParseIPAddress(ipAddress)
+	if err != nil {
+		return "", err
+	}
+	sharePath = NormalizeNFSSharePath(sharePath)
+	if ip.To4() != nil {
+		return fmt.Sprintf("%s:%s", ip.String(), sharePath), nil
+	}
+	return fmt.Sprintf("[%s]:%s", ip.String(), sharePath), nil
+}
+
+//NormalizeNFSSharePath function returns the NFS share path with a single leading slash and without trailing slash,
+//except for the root path (Ex: "export/", "//export" and "/export" all return "/export"). An empty path is returned as is.
+func NormalizeNFSSharePath(sharePath string) string {
+	if sharePath == "" {
+		return ""
+	}
+	return path.Clean("/" + sharePath)
+}
@@ -1,9 +1,13 @@
 package util
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/sirupsen/logrus"
 )
 
 var ctx context.Context
@@ -16,6 +20,9 @@ func TestUtils(t *testing.T) {
 	getLoggetTest(t)
 	validateResourceNameTest(t)
 	validateDurationTest(t)
+	ipAddressTest(t)
+	getNFSExportPathTest(t)
+	normalizeNFSSharePathTest(t)
 }
 
 func getRunIDLoggerTest(t *testing.T) {
@@ -34,6 +41,24 @@ func getRunIDLoggerTest(t *testing.T) {
 	logEntry = GetRunIDLogger(ctx)
 	logEntry.Info("Hi This is log test2")
 
+	output := &bytes.Buffer{}
+	customLog := logrus.New()
+	customLog.SetOutput(output)
+	ctx = WithLogger(ctx, logrus.NewEntry(customLog))
+	GetRunIDLogger(ctx).Info("Hi This is log test3")
+	if !strings.Contains(output.String(), "log test3") {
+		t.Fatalf("Logger set with WithLogger not used, output: %s", output.String())
+	}
+
+	clientOutput := &bytes.Buffer{}
+	clientLog := logrus.New()
+	clientLog.SetOutput(clientOutput)
+	ctx = context.WithValue(context.Background(), UnityLog, entry)
+	GetRunIDLoggerFor(ctx, clientLog).Info("Hi This is log test4")
+	if !strings.Contains(clientOutput.String(), "log test4") || !strings.Contains(clientOutput.String(), "runid=1111") {
+		t.Fatalf("Logger given to GetRunIDLoggerFor not used with the entry fields, output: %s", clientOutput.String())
+	}
+
 	fmt.Println("Get RunId Logger Test Successful")
 }
 
@@ -132,3 +157,86 @@ func validateDurationTest(t *testing.T) {
 	fmt.Println("Error: ", err)
 	fmt.Println("Validate Duration Test Successful")
 }
+
+func ipAddressTest(t *testing.T) {
+	fmt.Println("Begin - IP Address Test")
+
+	if !IsIPv6Address("fd00::10") || !IsIPv6Address("[fd00::10]") || !IsIPv6Address("fe80::1%eth0") {
+		t.Fatalf("IsIPv6Address failed for valid IPv6 address")
+	}
+	if IsIPv6Address("10.0.0.1") || IsIPv6Address("dummy-ip") {
+		t.Fatalf("IsIPv6Address Negative test failed")
+	}
+	_, err := ParseIPAddress("10.0.0.256")
+	if err != ErrorInvalidIPAddress {
+		t.Fatalf("ParseIPAddress Negative test failed: %v", err)
+	}
+
+	err = ValidateIPPrefixLength("10.0.0.1", 24)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	err = ValidateIPPrefixLength("fd00::10", 64)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	err = ValidateIPPrefixLength("10.0.0.1", 64)
+	if err == nil {
+		t.Fatalf("ValidateIPPrefixLength Negative test failed: %v", err)
+	}
+	err = ValidateIPPrefixLength("fd00::10", 129)
+	if err == nil {
+		t.Fatalf("ValidateIPPrefixLength Negative test failed: %v", err)
+	}
+	fmt.Println("IP Address Test Successful")
+}
+
+func getNFSExportPathTest(t *testing.T) {
+	fmt.Println("Begin - Get NFS Export Path Test")
+
+	testCases := map[string][]string{
+		"10.0.0.1:/fs1":     {"10.0.0.1", "/fs1"},
+		"10.0.0.1:/fs2":     {"10.0.0.1", "fs2"},
+		"[fd00::10]:/fs1":   {"fd00::10", "/fs1"},
+		"[fd00::10]:/fs2":   {"[fd00::10]", "/fs2"},
+		"[2001:db8::1]:/":   {"2001:0db8:0000::1", "/"},
+		"[fe80::1]:/snap-1": {"fe80::1%eth0", "snap-1"},
+	}
+	for expected, args := range testCases {
+		exportPath, err := GetNFSExportPath(args[0], args[1])
+		if err != nil || exportPath != expected {
+			t.Fatalf("GetNFSExportPath(%s, %s) returned %s, expected %s. Error: %v", args[0], args[1], exportPath, expected, err)
+		}
+	}
+
+	_, err := GetNFSExportPath("dummy-ip", "/fs1")
+	if err == nil {
+		t.Fatalf("GetNFSExportPath Negative test failed: %v", err)
+	}
+	fmt.Println("Get NFS Export Path Test Successful")
+}
+
+func normalizeNFSSharePathTest(t *testing.T) {
+	fmt.Println("Begin - Normalize NFS Share Path Test")
+
+	testCases := map[string]string{
+		"/export":         "/export",
+		"export":          "/export",
+		"export/":         "/export",
+		"//export":        "/export",
+		"/export//":       "/export",
+		"/export/dir1/":   "/export/dir1",
+		"export//dir1":    "/export/dir1",
+		"/":               "/",
+		"//":              "/",
+		"":                "",
+		"/export/./dir1/": "/export/dir1",
+	}
+	for sharePath, expected := range testCases {
+		normalized := NormalizeNFSSharePath(sharePath)
+		if normalized != expected {
+			t.Fatalf("NormalizeNFSSharePath(%s) returned %s, expected %s", sharePath, normalized, expected)
+		}
+	}
+	fmt.Println("Normalize NFS Share Path Test Successful")
+}
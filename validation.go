@@ -0,0 +1,122 @@
+package gounity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/dell/gounity/types"
+)
+
+//ValidateFilesystemName validates the name of a filesystem before the create or rename request: not empty, at most
+//FsNameMaxLength characters (the limit CreateFilesystem always enforced), without leading or trailing spaces and
+//without control characters. The Unity REST API reference documents the name of a filesystem as a free form string, it
+//has no list of allowed characters or reserved names, hence no other character is rejected here; the array remains
+//the authority for the names it refuses (Ex: a name already used on the NAS server).
+func ValidateFilesystemName(name string) error {
+	switch {
+	case name == "":
+		return errors.New("filesystem name should not be empty")
+	case len(name) > FsNameMaxLength:
+		return fmt.Errorf("filesystem name %s should not exceed %d characters", name, FsNameMaxLength)
+	case strings.TrimSpace(name) != name:
+		return fmt.Errorf("filesystem name '%s' should not start or end with spaces", name)
+	case strings.IndexFunc(name, unicode.IsControl) >= 0:
+		return fmt.Errorf("filesystem name %q should not contain control characters", name)
+	}
+	return nil
+}
+
+//FieldError is a problem found with a field of a request, the field is identified by it's JSON path in the request
+//(Ex: fsParameters.size)
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+//ValidationError collects all the problems found validating a request before it is sent to the array, so that they can
+//be presented at once. Callers get it with errors.As.
+type ValidationError struct {
+	Request  string
+	Problems []FieldError
+}
+
+//Error returns all the problems of the request
+func (e *ValidationError) Error() string {
+	problems := make([]string, 0, len(e.Problems))
+	for _, problem := range e.Problems {
+		problems = append(problems, problem.Field+": "+problem.Message)
+	}
+	return fmt.Sprintf("invalid %s request: %s", e.Request, strings.Join(problems, "; "))
+}
+
+//add records a problem with the given field
+func (e *ValidationError) add(field, format string, args ...interface{}) {
+	e.Problems = append(e.Problems, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+//errorOrNil returns the validation error, nil if no problem is found
+func (e *ValidationError) errorOrNil() error {
+	if len(e.Problems) == 0 {
+		return nil
+	}
+	return e
+}
+
+//validateFsCreateParam validates the filesystem create request before it is sent to the array.
+//All the problems found are reported together in the returned ValidationError.
+func validateFsCreateParam(param *types.FsCreateParam) *ValidationError {
+	validationErr := &ValidationError{Request: "filesystem create"}
+	if err := ValidateFilesystemName(param.Name); err != nil {
+		validationErr.add("name", "%v", err)
+	}
+
+	fsParams := param.FsParameters
+	if fsParams == nil {
+		validationErr.add("fsParameters", "filesystem parameters should not be empty")
+		return validationErr
+	}
+	if fsParams.Size == 0 {
+		validationErr.add("fsParameters.size", "size should be greater than 0")
+	} else if fsParams.Size > math.MaxInt64 {
+		//a negative size converted to uint64 ends up here
+		validationErr.add("fsParameters.size", "size %d is out of range", fsParams.Size)
+	}
+	if !isValidHostIOSize(fsParams.HostIOSize) {
+		validationErr.add("fsParameters.hostIOSize", "host IO size %d is not supported, valid values are %v", fsParams.HostIOSize, validHostIOSizes)
+	}
+	if fsParams.StoragePool == nil || fsParams.StoragePool.PoolID == "" {
+		validationErr.add("fsParameters.pool", "storage pool should not be empty")
+	}
+	if fsParams.NasServer == nil || fsParams.NasServer.NasServerID == "" {
+		validationErr.add("fsParameters.nasServer", "NAS server should not be empty")
+	}
+	return validationErr
+}
+
+//isValidHostIOSize checks the hostIOSize against the values the array accepts
+func isValidHostIOSize(hostIOSize int) bool {
+	for _, size := range validHostIOSizes {
+		if hostIOSize == size {
+			return true
+		}
+	}
+	return false
+}
+
+//validateNFSShareCreateParam validates the NFS share create request before it is sent to the array.
+//All the problems found are reported together in the returned error.
+func validateNFSShareCreateParam(param *types.NFSShareCreateParam) error {
+	validationErr := &ValidationError{Request: "NFS share create"}
+	if param.Name == "" {
+		validationErr.add("name", "name should not be empty")
+	}
+	if param.Path == "" {
+		validationErr.add("path", "path should not be empty")
+	} else if !strings.HasPrefix(param.Path, "/") {
+		validationErr.add("path", "path %s should be absolute", param.Path)
+	}
+	return validationErr.errorOrNil()
+}
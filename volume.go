@@ -16,7 +16,6 @@ import (
 
 	"github.com/dell/gounity/api"
 	"github.com/dell/gounity/types"
-	"github.com/dell/gounity/util"
 )
 
 //LicenseType is string
@@ -66,7 +65,7 @@ func NewVolume(client *Client) *Volume {
 //                  2. Size of Lun should be in bytes.
 func (v *Volume) CreateLun(ctx context.Context, name, poolID, description string, size uint64, fastVPTieringPolicy int,
 	hostIOLimitID string, isThinEnabled, isDataReductionEnabled bool) (*types.Volume, error) {
-	log := util.GetRunIDLogger(ctx)
+	log := v.client.getLogger(ctx)
 
 	if name == "" {
 		return nil, errors.New("lun name should not be empty")
@@ -147,6 +146,13 @@ func (v *Volume) CreateLun(ctx context.Context, name, poolID, description string
 	volumeResp := &types.Volume{}
 	err = v.client.executeWithRetryAuthenticate(ctx,
 		http.MethodPost, fmt.Sprintf(api.UnityAPIStorageResourceActionURI, api.CreateLunAction), volumeReqParam, volumeResp)
+	if isResponseLost(err) {
+		existing, findErr := v.FindVolumeByName(ctx, name)
+		if findErr == nil && existing.VolumeContent.Pool.ID == poolID {
+			log.Warnf("Create volume %s response lost, volume %s found created. Error: %v", name, existing.VolumeContent.ResourceID, err)
+			return existing, nil
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -154,12 +160,13 @@ func (v *Volume) CreateLun(ctx context.Context, name, poolID, description string
 }
 
 //FindVolumeByName - Find the volume by it's name. If the volume is not found, an error will be returned.
-func (v *Volume) FindVolumeByName(ctx context.Context, volName string) (*types.Volume, error) {
+//Only the given fields are fetched when any, LunDisplayFields otherwise.
+func (v *Volume) FindVolumeByName(ctx context.Context, volName string, fields ...string) (*types.Volume, error) {
 	if len(volName) == 0 {
 		return nil, fmt.Errorf("lun Name shouldn't be empty")
 	}
 	volumeResp := &types.Volume{}
-	err := v.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceByNameWithFieldsURI, api.LunAction, volName, LunDisplayFields), nil, volumeResp)
+	err := v.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceByNameWithFieldsURI, api.LunAction, volName, displayFields(LunDisplayFields, fields)), nil, volumeResp)
 	if err != nil {
 		return nil, fmt.Errorf("unable to find volume by name %s", volName)
 	}
@@ -168,13 +175,14 @@ func (v *Volume) FindVolumeByName(ctx context.Context, volName string) (*types.V
 }
 
 //FindVolumeByID - Find the volume by it's Id. If the volume is not found, an error will be returned.
-func (v *Volume) FindVolumeByID(ctx context.Context, volID string) (*types.Volume, error) {
-	log := util.GetRunIDLogger(ctx)
+//Only the given fields are fetched when any, LunDisplayFields otherwise.
+func (v *Volume) FindVolumeByID(ctx context.Context, volID string, fields ...string) (*types.Volume, error) {
+	log := v.client.getLogger(ctx)
 	if len(volID) == 0 {
 		return nil, errors.New("lun ID shouldn't be empty")
 	}
 	volumeResp := &types.Volume{}
-	err := v.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceWithFieldsURI, api.LunAction, volID, LunDisplayFields), nil, volumeResp)
+	err := v.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceWithFieldsURI, api.LunAction, volID, displayFields(LunDisplayFields, fields)), nil, volumeResp)
 	if err != nil {
 		if strings.Contains(err.Error(), VolumeNotFoundErrorCode) {
 			log.Debugf("Unable to find volume Id %s Error: %v", volID, err)
@@ -187,7 +195,7 @@ func (v *Volume) FindVolumeByID(ctx context.Context, volID string) (*types.Volum
 
 //ListVolumes - list volumes
 func (v *Volume) ListVolumes(ctx context.Context, startToken int, maxEntries int) ([]types.Volume, int, error) {
-	log := util.GetRunIDLogger(ctx)
+	log := v.client.getLogger(ctx)
 	volumeResp := &types.ListVolumes{}
 	nextToken := startToken + 1
 	lunURI := fmt.Sprintf(api.UnityAPIInstanceTypeResourcesWithFields, api.LunAction, LunDisplayFields)
@@ -210,7 +218,7 @@ func (v *Volume) ListVolumes(ctx context.Context, startToken int, maxEntries int
 
 //DeleteVolume - Delete Volume by its ID. If the Volume is not present on the array, an error will be returned.
 func (v *Volume) DeleteVolume(ctx context.Context, volumeID string) error {
-	log := util.GetRunIDLogger(ctx)
+	log := v.client.getLogger(ctx)
 	if len(volumeID) == 0 {
 		return errors.New("Volume Id cannot be empty")
 	}
@@ -312,6 +320,72 @@ func (v *Volume) ModifyVolumeExport(ctx context.Context, volID string, hostIDLis
 	return v.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityModifyLunURI, volID), lunModifyParam, nil)
 }
 
+//LUN host access constants, the access a host has to a LUN and to it's snapshots
+const (
+	LunNoAccess                    = 0 //Removes the host from the LUN host access list
+	LunProductionAccess            = 1 //Access to the LUN
+	LunSnapshotAccess              = 2 //Access to the snapshots of the LUN
+	LunProductionAndSnapshotAccess = 3 //Access to the LUN and it's snapshots
+)
+
+//ModifyLunHostAccess - Set the access of the given hosts to the LUN, the access of other hosts is kept unchanged.
+//The hosts are removed from the LUN host access list with LunNoAccess. All the hosts must exist on the array.
+func (v *Volume) ModifyLunHostAccess(ctx context.Context, lunID string, hostIDs []string, accessType int) error {
+	log := v.client.getLogger(ctx)
+	if accessType < LunNoAccess || accessType > LunProductionAndSnapshotAccess {
+		return fmt.Errorf("invalid LUN host access type: %d", accessType)
+	}
+
+	vol, err := v.FindVolumeByID(ctx, lunID)
+	if err != nil {
+		return err
+	}
+
+	hostAPI := NewHost(v.client)
+	for _, hostID := range hostIDs {
+		if _, err = hostAPI.FindHostByID(ctx, hostID); err != nil {
+			return fmt.Errorf("unable to find host %s. Error: %v", hostID, err)
+		}
+	}
+
+	accessMasks := make(map[string]int)
+	hostOrder := []string{}
+	for _, hostAccess := range vol.VolumeContent.HostAccessResponse {
+		hostID := hostAccess.HostContent.ID
+		accessMasks[hostID] = hostAccess.AccessMask
+		hostOrder = append(hostOrder, hostID)
+	}
+	for _, hostID := range hostIDs {
+		if _, ok := accessMasks[hostID]; !ok {
+			hostOrder = append(hostOrder, hostID)
+		}
+		accessMasks[hostID] = accessType
+	}
+
+	hostAccessArray := []types.HostAccess{}
+	for _, hostID := range hostOrder {
+		if accessMasks[hostID] == LunNoAccess {
+			continue
+		}
+		hostAccessArray = append(hostAccessArray, types.HostAccess{
+			HostIDContent: &types.HostIDContent{ID: hostID},
+			AccessMask:    strconv.Itoa(accessMasks[hostID]),
+		})
+	}
+
+	lunModifyParam := types.LunHostAccessModifyParam{
+		LunHostAccessParameters: &types.LunHostAccessParameters{
+			HostAccess: &hostAccessArray,
+		},
+	}
+	err = v.client.executeWithRetryAuthenticate(ctx, http.MethodPost, fmt.Sprintf(api.UnityModifyLunURI, lunID), lunModifyParam, nil)
+	if err != nil {
+		return fmt.Errorf("modify LUN %s host access failed. Error: %v", lunID, err)
+	}
+	log.Debugf("Modify LUN %s host access of hosts %v to %d successful", lunID, hostIDs, accessType)
+	return nil
+}
+
 //UnexportVolume - Unexport volume
 func (v *Volume) UnexportVolume(ctx context.Context, volID string) error {
 	hostAccessArray := []types.HostAccess{}
@@ -326,7 +400,7 @@ func (v *Volume) UnexportVolume(ctx context.Context, volID string) error {
 
 //ExpandVolume - Expand volume to provided capacity
 func (v *Volume) ExpandVolume(ctx context.Context, volumeID string, newSize uint64) error {
-	log := util.GetRunIDLogger(ctx)
+	log := v.client.getLogger(ctx)
 	vol, err := v.FindVolumeByID(ctx, volumeID)
 	if err != nil {
 		return fmt.Errorf("unable to find volume Id %s Error: %v", volumeID, err)
@@ -383,9 +457,24 @@ func (v *Volume) isFeatureLicensed(ctx context.Context, featureName LicenseType)
 	return licenseInfoResp, nil
 }
 
+//GetFeatureLicenseExpiry - Get the expiration time of the license of the given feature, and whether the license is
+//permanent (the expiration time is not meaningful then). An error is returned if the license is not installed.
+func (v *Volume) GetFeatureLicenseExpiry(ctx context.Context, featureName LicenseType) (time.Time, bool, error) {
+	licenseInfoResp := &types.LicenseInfo{}
+	err := v.client.executeWithRetryAuthenticate(ctx, http.MethodGet, fmt.Sprintf(api.UnityAPIGetResourceByNameWithFieldsURI, api.LicenseAction, featureName, LicenseExpiryDisplayFields), nil, licenseInfoResp)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("unable to get license info for feature: %s. Error: %v", featureName, err)
+	}
+	license := licenseInfoResp.LicenseInfoContent
+	if !license.IsInstalled {
+		return time.Time{}, false, fmt.Errorf("license for feature: %s is not installed", featureName)
+	}
+	return license.Expires, license.IsPermanent, nil
+}
+
 //CreateCloneFromVolume - Volume cloning
 func (v *Volume) CreateCloneFromVolume(ctx context.Context, name, volID string) (*types.Volume, error) {
-	log := util.GetRunIDLogger(ctx)
+	log := v.client.getLogger(ctx)
 	snapAPI := NewSnapshot(v.client)
 	//Create snapshot for cloning
 	snapName := SnapForClone + strconv.FormatInt(time.Now().Unix(), 10)
@@ -14,6 +14,7 @@ var cloneVolumeID string
 var hostIOLimitID string
 
 func TestVolume(t *testing.T) {
+	requireArray(t)
 	now := time.Now()
 	timeStamp := now.Format("20060102150405")
 	volName = "Unit-test-vol-" + timeStamp
@@ -21,6 +22,7 @@ func TestVolume(t *testing.T) {
 	ctx = context.Background()
 
 	findHostIOLimitByNameTest(t)
+	getFeatureLicenseExpiryTest(t)
 	createLunTest(t)
 	findVolumeByNameTest(t)
 	findVolumeByIDTest(t)
@@ -34,6 +36,25 @@ func TestVolume(t *testing.T) {
 
 }
 
+func getFeatureLicenseExpiryTest(t *testing.T) {
+
+	fmt.Println("Begin - Get Feature License Expiry Test")
+
+	expires, isPermanent, err := testConf.volumeAPI.GetFeatureLicenseExpiry(ctx, ThinProvisioning)
+	if err != nil {
+		t.Fatalf("Get feature license expiry failed: %v", err)
+	}
+	fmt.Println("Thin provisioning license expires:", expires, "permanent:", isPermanent)
+
+	//Negative case
+	_, _, err = testConf.volumeAPI.GetFeatureLicenseExpiry(ctx, LicenseType("DUMMY_FEATURE"))
+	if err == nil {
+		t.Fatalf("Get feature license expiry of an invalid feature case - failed: %v", err)
+	}
+
+	fmt.Println("Get Feature License Expiry Test Successful")
+}
+
 func findHostIOLimitByNameTest(t *testing.T) {
 
 	fmt.Println("Begin - Find Host IO Limit by Name Test")
@@ -185,6 +206,21 @@ func exportVolumeTest(t *testing.T) {
 		t.Fatalf("Delete volume on exported volume case failed: %v", err)
 	}
 
+	err = testConf.volumeAPI.ModifyLunHostAccess(ctx, volID, []string{host.HostContent.ID}, LunProductionAndSnapshotAccess)
+	if err != nil {
+		t.Fatalf("Modify LUN host access failed: %v", err)
+	}
+
+	err = testConf.volumeAPI.ModifyLunHostAccess(ctx, volID, []string{"dummy_host_1"}, LunProductionAccess)
+	if err == nil {
+		t.Fatalf("Modify LUN host access with invalid host case failed: %v", err)
+	}
+
+	err = testConf.volumeAPI.ModifyLunHostAccess(ctx, volID, []string{host.HostContent.ID}, 7)
+	if err == nil {
+		t.Fatalf("Modify LUN host access with invalid access type case failed: %v", err)
+	}
+
 	fmt.Println("Export Volume Test - Successful")
 }
 
@@ -192,7 +228,17 @@ func unexportVolumeTest(t *testing.T) {
 
 	fmt.Println("Begin - Unexport Volume Test")
 
-	err := testConf.volumeAPI.UnexportVolume(ctx, volID)
+	host, err := testConf.hostAPI.FindHostByName(ctx, testConf.nodeHostName)
+	if err != nil {
+		t.Fatalf("Find Host failed: %v", err)
+	}
+
+	err = testConf.volumeAPI.ModifyLunHostAccess(ctx, volID, []string{host.HostContent.ID}, LunNoAccess)
+	if err != nil {
+		t.Fatalf("Remove LUN host access failed: %v", err)
+	}
+
+	err = testConf.volumeAPI.UnexportVolume(ctx, volID)
 	if err != nil {
 		t.Fatalf("UnExportVolume failed: %v", err)
 	}